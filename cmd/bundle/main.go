@@ -6,10 +6,15 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
@@ -24,12 +29,20 @@ import (
 type settings struct {
 	help                      bool
 	image                     string
+	url                       string
+	push                      string
+	reproducible              bool
+	checksum                  string
 	prune                     bool
 	target                    string
 	secretPath                string
 	resultFileImageDigest     string
+	resultFileArchiveDigest   string
 	resultFileSourceTimestamp string
+	resultFileErrorMessage    string
+	resultFileErrorReason     string
 	showListing               bool
+	limits                    bundle.UnpackLimits
 }
 
 var flagValues settings
@@ -39,10 +52,21 @@ func init() {
 	pflag.BoolVar(&flagValues.help, "help", false, "Print the help")
 
 	// Main flags of the bundle step
-	pflag.StringVar(&flagValues.image, "image", "", "Location of the bundle image (mandatory)")
+	pflag.StringVar(&flagValues.image, "image", "", "Location of the bundle image (mandatory, unless --url is set)")
+	pflag.StringVar(&flagValues.url, "url", "", "Location of a .tar.gz, .tar.zst, or .zip archive to download instead of a bundle image")
+	pflag.StringVar(&flagValues.push, "push", "", "A directory to pack and push as bundle image to --image, instead of pulling the image")
+	pflag.BoolVar(&flagValues.reproducible, "reproducible", false, "Pack the directory of --push reproducibly, with the modification times set to $SOURCE_DATE_EPOCH or the Unix epoch")
+	pflag.StringVar(&flagValues.checksum, "checksum", "", "The expected checksum of the archive, either sha256:<hex> or sha512:<hex> (optional)")
 	pflag.StringVar(&flagValues.target, "target", "/workspace/source", "The target directory to place the code")
 	pflag.StringVar(&flagValues.resultFileImageDigest, "result-file-image-digest", "", "A file to write the image digest")
+	pflag.StringVar(&flagValues.resultFileArchiveDigest, "result-file-archive-digest", "", "A file to write the sha256 digest of the archive")
 	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp")
+	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to")
+	pflag.StringVar(&flagValues.resultFileErrorReason, "result-file-error-reason", "", "A file to write the error reason to")
+
+	pflag.Int64Var(&flagValues.limits.MaxSize, "max-size", 0, "The maximum total size in bytes of the unpacked files, zero means no limit")
+	pflag.Int64Var(&flagValues.limits.MaxFileSize, "max-file-size", 0, "The maximum size in bytes of an unpacked file, zero means no limit")
+	pflag.Int64Var(&flagValues.limits.MaxEntries, "max-entries", 0, "The maximum number of unpacked files and directories, zero means no limit")
 
 	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains access credentials (optional)")
 	pflag.BoolVar(&flagValues.prune, "prune", false, "Delete bundle image from registry after it was pulled")
@@ -56,10 +80,20 @@ func main() {
 }
 
 // Do is the main entry point of the bundle command
-func Do(ctx context.Context) error {
+func Do(ctx context.Context) (err error) {
 	flagValues = settings{}
 	pflag.Parse()
 
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if writeErr := writeErrorResults(err); writeErr != nil {
+			log.Printf("Could not write error results: %s", writeErr.Error())
+		}
+	}()
+
 	if val, ok := os.LookupEnv("BUNDLE_SHOW_LISTING"); ok {
 		flagValues.showListing, _ = strconv.ParseBool(val)
 	}
@@ -69,6 +103,26 @@ func Do(ctx context.Context) error {
 		return nil
 	}
 
+	if flagValues.push != "" {
+		if flagValues.url != "" || flagValues.prune {
+			return fmt.Errorf("flag --push is mutually exclusive with --url and --prune")
+		}
+
+		if flagValues.image == "" {
+			return fmt.Errorf("mandatory flag --image is not set")
+		}
+
+		return doPush(ctx)
+	}
+
+	if flagValues.url != "" {
+		if flagValues.image != "" {
+			return fmt.Errorf("flags --image and --url are mutually exclusive")
+		}
+
+		return doArchive(ctx)
+	}
+
 	if flagValues.image == "" {
 		return fmt.Errorf("mandatory flag --image is not set")
 	}
@@ -104,7 +158,7 @@ func Do(ctx context.Context) error {
 	rc := mutate.Extract(img)
 	defer rc.Close()
 
-	unpackDetails, err := bundle.Unpack(rc, flagValues.target)
+	unpackDetails, err := bundle.UnpackWithLimits(rc, flagValues.target, flagValues.limits)
 	if err != nil {
 		return err
 	}
@@ -126,15 +180,8 @@ func Do(ctx context.Context) error {
 		}
 	}
 
-	if flagValues.resultFileSourceTimestamp != "" {
-		if unpackDetails.MostRecentFileTimestamp != nil {
-			if err = os.WriteFile(flagValues.resultFileSourceTimestamp, []byte(strconv.FormatInt(unpackDetails.MostRecentFileTimestamp.Unix(), 10)), 0644); err != nil {
-				return err
-			}
-
-		} else {
-			log.Printf("Unable to determine source timestamp of content in %s\n", flagValues.target)
-		}
+	if err := writeSourceTimestamp(unpackDetails); err != nil {
+		return err
 	}
 
 	if flagValues.prune {
@@ -154,3 +201,175 @@ func Do(ctx context.Context) error {
 
 	return nil
 }
+
+// doPush packs the directory, without the files that its .shpignore file excludes, and pushes it as bundle image.
+// The reference of the image with its digest is printed, so that it can be used as source of a Build or BuildRun.
+func doPush(ctx context.Context) error {
+	if info, err := os.Stat(flagValues.push); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", flagValues.push)
+	}
+
+	ref, err := name.ParseReference(flagValues.image)
+	if err != nil {
+		return err
+	}
+
+	options, _, err := image.GetOptions(ctx, ref, true, flagValues.secretPath, "Shipwright Build")
+	if err != nil {
+		return err
+	}
+
+	packingOptions, err := packOptions()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Pushing the content of %s as image %q", flagValues.push, ref)
+	digest, err := bundle.PackAndPushWithOptions(ref, flagValues.push, packingOptions, options...)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Pushed image %q\n", digest)
+	fmt.Fprintln(os.Stdout, digest.String())
+
+	if flagValues.resultFileImageDigest != "" {
+		return os.WriteFile(flagValues.resultFileImageDigest, []byte(digest.DigestStr()), 0644)
+	}
+
+	return nil
+}
+
+// packOptions returns the options to pack the directory, a reproducible bundle uses the time of the
+// SOURCE_DATE_EPOCH environment variable as modification time, see https://reproducible-builds.org/specs/source-date-epoch/
+func packOptions() (bundle.PackOptions, error) {
+	options := bundle.PackOptions{Reproducible: flagValues.reproducible}
+	if !flagValues.reproducible {
+		return options, nil
+	}
+
+	if value := os.Getenv("SOURCE_DATE_EPOCH"); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return options, fmt.Errorf("SOURCE_DATE_EPOCH is not a Unix timestamp: %q", value)
+		}
+
+		options.SourceDateEpoch = time.Unix(seconds, 0)
+	}
+
+	return options, nil
+}
+
+// doArchive downloads the archive, verifies its checksum, and extracts it into the target directory
+func doArchive(ctx context.Context) error {
+	format, err := bundle.ArchiveFormatOf(flagValues.url)
+	if err != nil {
+		return err
+	}
+
+	var checksum *bundle.Checksum
+	if flagValues.checksum != "" {
+		if checksum, err = bundle.ParseChecksum(flagValues.checksum); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.CreateTemp("", "source-archive-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	client, err := httpClient()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Downloading archive %q", flagValues.url)
+	downloadDetails, err := bundle.Download(ctx, client, flagValues.url, file, checksum, flagValues.limits.MaxSize)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	unpackDetails, err := bundle.UnpackArchive(file, format, flagValues.target, flagValues.limits)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Archive content was extracted to %s\n", flagValues.target)
+	if flagValues.showListing {
+		// ignore any errors when walking through the file system, the listing is only for informational purposes
+		_ = util.ListFiles(log.Writer(), flagValues.target)
+	}
+
+	if flagValues.resultFileArchiveDigest != "" {
+		if err = os.WriteFile(flagValues.resultFileArchiveDigest, []byte(downloadDetails.Digest), 0644); err != nil {
+			return err
+		}
+	}
+
+	return writeSourceTimestamp(unpackDetails)
+}
+
+// writeSourceTimestamp writes the timestamp of the most recent unpacked file to the result file
+func writeSourceTimestamp(unpackDetails *bundle.UnpackDetails) error {
+	if flagValues.resultFileSourceTimestamp == "" {
+		return nil
+	}
+
+	if unpackDetails.MostRecentFileTimestamp == nil {
+		log.Printf("Unable to determine source timestamp of content in %s\n", flagValues.target)
+		return nil
+	}
+
+	return os.WriteFile(flagValues.resultFileSourceTimestamp, []byte(strconv.FormatInt(unpackDetails.MostRecentFileTimestamp.Unix(), 10)), 0644)
+}
+
+// writeErrorResults writes the message and the reason of errors that have a failure reason, which are
+// the errors of source bundles that exceed one of the limits
+func writeErrorResults(err error) error {
+	if flagValues.resultFileErrorMessage == "" || flagValues.resultFileErrorReason == "" {
+		return nil
+	}
+
+	var limitExceededError *bundle.LimitExceededError
+	if !errors.As(err, &limitExceededError) {
+		return nil
+	}
+
+	if err := os.WriteFile(flagValues.resultFileErrorMessage, []byte(limitExceededError.Error()), 0644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(flagValues.resultFileErrorReason, []byte(bundle.LimitExceededReason), 0644)
+}
+
+// httpClient returns the client to download the archive, it trusts the certificates of the configured CA bundle
+// in addition to the system roots
+func httpClient() (*http.Client, error) {
+	rootCAs, err := util.RootCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	if rootCAs == nil {
+		return http.DefaultClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
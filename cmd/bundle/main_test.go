@@ -5,13 +5,22 @@
 package main_test
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -315,6 +324,63 @@ var _ = Describe("Bundle Loader", func() {
 		})
 	})
 
+	Context("Unpacking with limits", func() {
+		withBundleImage := func(f func(image string)) {
+			withTempRegistry(func(endpoint string) {
+				withTempDir(func(source string) {
+					Expect(os.WriteFile(filepath.Join(source, "small"), bytes.Repeat([]byte("x"), 10), 0644)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(source, "large"), bytes.Repeat([]byte("x"), 2048), 0644)).To(Succeed())
+
+					ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/image:tag", endpoint))
+					Expect(err).ToNot(HaveOccurred())
+
+					dig, err := bundle.PackAndPush(ref, source)
+					Expect(err).ToNot(HaveOccurred())
+
+					f(dig.String())
+				})
+			})
+		}
+
+		It("should unpack a bundle that is within the limits", func() {
+			withBundleImage(func(image string) {
+				withTempDir(func(target string) {
+					Expect(run(
+						"--image", image,
+						"--target", target,
+						"--max-size", "4096",
+						"--max-file-size", "2048",
+						"--max-entries", "3",
+					)).To(Succeed())
+
+					Expect(filepath.Join(target, "large")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should fail with an error reason for a bundle that exceeds a limit", func() {
+			withBundleImage(func(image string) {
+				withTempDir(func(target string) {
+					withTempFile("error-message", func(errorMessage string) {
+						withTempFile("error-reason", func(errorReason string) {
+							Expect(run(
+								"--image", image,
+								"--target", target,
+								"--max-file-size", "1024",
+								"--result-file-error-message", errorMessage,
+								"--result-file-error-reason", errorReason,
+							)).To(MatchError("the file large of the source bundle has 2048 bytes, which exceeds the limit of 1024 bytes"))
+
+							Expect(filecontent(errorMessage)).To(Equal("the file large of the source bundle has 2048 bytes, which exceeds the limit of 1024 bytes"))
+							Expect(filecontent(errorReason)).To(Equal(bundle.LimitExceededReason))
+							Expect(filepath.Join(target, "large")).ToNot(BeAnExistingFile())
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Context("Using show listing flag", func() {
 		It("should run without issues", func() {
 			withTempDir(func(target string) {
@@ -326,4 +392,228 @@ var _ = Describe("Bundle Loader", func() {
 			})
 		})
 	})
+
+	Context("Pushing a directory", func() {
+		withSourceDirectory := func(f func(source string)) {
+			withTempDir(func(source string) {
+				Expect(os.WriteFile(filepath.Join(source, "main.go"), []byte("package main"), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(source, "secret.env"), []byte("TOKEN=foobar"), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(source, ".shpignore"), []byte("*.env\n"), 0644)).To(Succeed())
+
+				f(source)
+			})
+		}
+
+		// captureStdout returns what the function printed to stdout
+		captureStdout := func(f func()) string {
+			reader, writer, err := os.Pipe()
+			Expect(err).ToNot(HaveOccurred())
+
+			var tmp = os.Stdout
+			os.Stdout = writer
+			defer func() { os.Stdout = tmp }()
+
+			f()
+
+			Expect(writer.Close()).To(Succeed())
+			output, err := io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			return string(output)
+		}
+
+		It("should push a directory as bundle image and print its digest", func() {
+			withSourceDirectory(func(source string) {
+				withTempRegistry(func(endpoint string) {
+					withTempFile("image-digest", func(resultImageDigest string) {
+						tag, err := name.NewTag(fmt.Sprintf("%s/namespace/source:latest", endpoint))
+						Expect(err).ToNot(HaveOccurred())
+
+						output := captureStdout(func() {
+							Expect(run(
+								"--push", source,
+								"--image", tag.String(),
+								"--result-file-image-digest", resultImageDigest,
+							)).To(Succeed())
+						})
+
+						digest := getImageDigest(tag)
+						Expect(output).To(Equal(fmt.Sprintf("%s@%s\n", tag.Name(), digest.String())))
+						Expect(filecontent(resultImageDigest)).To(Equal(digest.String()))
+
+						withTempDir(func(target string) {
+							Expect(run(
+								"--image", strings.TrimSpace(output),
+								"--target", target,
+							)).To(Succeed())
+
+							Expect(filecontent(filepath.Join(target, "main.go"))).To(Equal("package main"))
+							Expect(filepath.Join(target, "secret.env")).ToNot(BeAnExistingFile())
+						})
+					})
+				})
+			})
+		})
+
+		It("should push identical directories reproducibly as the same image", func() {
+			GinkgoT().Setenv("SOURCE_DATE_EPOCH", "1700000000")
+
+			withTempRegistry(func(endpoint string) {
+				var outputs []string
+				for _, modTime := range []time.Time{time.Unix(1234567890, 0), time.Now()} {
+					withSourceDirectory(func(source string) {
+						Expect(os.Chtimes(filepath.Join(source, "main.go"), modTime, modTime)).To(Succeed())
+
+						outputs = append(outputs, captureStdout(func() {
+							Expect(run(
+								"--push", source,
+								"--image", fmt.Sprintf("%s/namespace/source:latest", endpoint),
+								"--reproducible",
+							)).To(Succeed())
+						}))
+					})
+				}
+
+				Expect(outputs[1]).To(Equal(outputs[0]))
+
+				withTempDir(func(target string) {
+					Expect(run(
+						"--image", strings.TrimSpace(outputs[0]),
+						"--target", target,
+					)).To(Succeed())
+
+					info, err := os.Stat(filepath.Join(target, "main.go"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(info.ModTime().Unix()).To(Equal(int64(1700000000)))
+				})
+			})
+		})
+
+		It("should fail when the directory does not exist", func() {
+			withTempRegistry(func(endpoint string) {
+				Expect(run(
+					"--push", "/does/not/exist",
+					"--image", fmt.Sprintf("%s/namespace/source:latest", endpoint),
+				)).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+		})
+
+		It("should fail when the image is not specified", func() {
+			withSourceDirectory(func(source string) {
+				Expect(run("--push", source)).To(MatchError("mandatory flag --image is not set"))
+			})
+		})
+
+		It("should fail when pruning is requested", func() {
+			withSourceDirectory(func(source string) {
+				Expect(run(
+					"--push", source,
+					"--image", "registry.example.com/namespace/source:latest",
+					"--prune",
+				)).To(MatchError("flag --push is mutually exclusive with --url and --prune"))
+			})
+		})
+	})
+
+	Context("Downloading an archive", func() {
+		var (
+			server  *httptest.Server
+			archive map[string][]byte
+		)
+
+		tarGz := func(files map[string]string) []byte {
+			var buf bytes.Buffer
+			gw := gzip.NewWriter(&buf)
+			tw := tar.NewWriter(gw)
+			for name, content := range files {
+				Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg, ModTime: time.Unix(1691650396, 0)})).To(Succeed())
+				_, err := tw.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(tw.Close()).To(Succeed())
+			Expect(gw.Close()).To(Succeed())
+			return buf.Bytes()
+		}
+
+		zipArchive := func(files map[string]string) []byte {
+			var buf bytes.Buffer
+			zw := zip.NewWriter(&buf)
+			for name, content := range files {
+				w, err := zw.Create(name)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = w.Write([]byte(content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(zw.Close()).To(Succeed())
+			return buf.Bytes()
+		}
+
+		BeforeEach(func() {
+			archive = map[string][]byte{
+				"/release-1.0.0.tar.gz": tarGz(map[string]string{"release-1.0.0/main.go": "package main"}),
+				"/release-1.0.0.zip":    zipArchive(map[string]string{"release-1.0.0/main.go": "package main"}),
+			}
+
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				content, ok := archive[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(content)
+			}))
+			DeferCleanup(server.Close)
+		})
+
+		It("should download and unpack a tar.gz archive", func() {
+			withTempDir(func(target string) {
+				withTempFile("archive-digest", func(digestFile string) {
+					withTempFile("source-timestamp", func(timestampFile string) {
+						Expect(run(
+							"--url", server.URL+"/release-1.0.0.tar.gz",
+							"--target", target,
+							"--result-file-archive-digest", digestFile,
+							"--result-file-source-timestamp", timestampFile,
+						)).To(Succeed())
+
+						Expect(filecontent(filepath.Join(target, "release-1.0.0", "main.go"))).To(Equal("package main"))
+						Expect(filecontent(digestFile)).To(Equal(fmt.Sprintf("sha256:%x", sha256.Sum256(archive["/release-1.0.0.tar.gz"]))))
+						Expect(filecontent(timestampFile)).To(Equal("1691650396"))
+					})
+				})
+			})
+		})
+
+		It("should download and unpack a zip archive with a matching checksum", func() {
+			withTempDir(func(target string) {
+				Expect(run(
+					"--url", server.URL+"/release-1.0.0.zip",
+					"--target", target,
+					"--checksum", fmt.Sprintf("sha512:%x", sha512.Sum512(archive["/release-1.0.0.zip"])),
+				)).To(Succeed())
+
+				Expect(filecontent(filepath.Join(target, "release-1.0.0", "main.go"))).To(Equal("package main"))
+			})
+		})
+
+		It("should fail and not unpack the archive when the checksum does not match", func() {
+			withTempDir(func(target string) {
+				Expect(run(
+					"--url", server.URL+"/release-1.0.0.tar.gz",
+					"--target", target,
+					"--checksum", fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("something else"))),
+				)).To(MatchError(ContainSubstring("checksum mismatch")))
+
+				Expect(filepath.Join(target, "release-1.0.0")).ToNot(BeADirectory())
+			})
+		})
+
+		It("should fail for unsupported archive formats", func() {
+			Expect(run("--url", server.URL+"/release-1.0.0.rar")).To(MatchError(ContainSubstring("unsupported archive")))
+		})
+
+		It("should fail when both an image and an URL are specified", func() {
+			Expect(run("--url", server.URL+"/release-1.0.0.zip", "--image", exampleImage)).To(MatchError(ContainSubstring("mutually exclusive")))
+		})
+	})
 })
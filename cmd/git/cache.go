@@ -0,0 +1,185 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// cacheLock is an exclusive lock on a mirror of the cache directory, it is held while the mirror is
+// updated and used as reference so that parallel BuildRuns of the same repository do not interfere
+type cacheLock struct {
+	file *os.File
+}
+
+func lockCache(path string, wait bool) (*cacheLock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+
+	if err := syscall.Flock(int(file.Fd()), how); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &cacheLock{file: file}, nil
+}
+
+func (l *cacheLock) unlock() {
+	_ = syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+}
+
+// cacheMirror returns the directory of the bare mirror of the repository in the cache directory
+func cacheMirror(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(flagValues.cacheDir, hex.EncodeToString(hash[:16])+".git")
+}
+
+// prepareCache creates or incrementally updates the mirror of the repository in the cache directory,
+// and evicts the least recently used mirrors of other repositories once the cache exceeds its maximum
+// size. It returns the mirror, which is locked until the returned function is called. The cache only
+// speeds up the clone, it therefore returns an empty mirror if it cannot be used.
+func prepareCache(ctx context.Context, addtlGitArgs []string) (string, func()) {
+	// the mirror holds all objects of the repository, a partial clone would download the objects that
+	// its filter omits into the mirror and copy them from there
+	if flagValues.filter != "" {
+		log.Printf("Cloning without the Git cache, it does not support a partial clone with the filter %s\n", flagValues.filter)
+		return "", func() {}
+	}
+
+	mirror := cacheMirror(flagValues.url)
+
+	lock, err := lockCache(mirror+".lock", true)
+	if err != nil {
+		log.Printf("Failed to lock the Git cache, cloning without it: %v\n", err)
+		return "", func() {}
+	}
+
+	// the mirror may be owned by the user of another build
+	gitArgs := []string{"-c", "safe.directory=" + mirror}
+	gitArgs = append(gitArgs, addtlGitArgs...)
+
+	if hasFile(filepath.Join(mirror, "HEAD")) {
+		log.Printf("Updating the Git cache %s\n", mirror)
+		_, err = git(ctx, append(gitArgs, "-C", mirror, "fetch", "--quiet", "--prune")...)
+	} else {
+		log.Printf("Populating the Git cache %s\n", mirror)
+		_ = os.RemoveAll(mirror)
+		if _, err = git(ctx, append(gitArgs, "clone", "--quiet", "--mirror", "--", flagValues.url, mirror)...); err != nil {
+			// do not leave an incomplete mirror behind
+			_ = os.RemoveAll(mirror)
+		}
+	}
+
+	if err != nil {
+		log.Printf("Failed to update the Git cache, cloning without it: %s\n", failureDetail(err))
+		lock.unlock()
+		return "", func() {}
+	}
+
+	// the modification time of the mirror tracks when it was used last
+	now := time.Now()
+	_ = os.Chtimes(mirror, now, now)
+
+	if flagValues.cacheMaxSize > 0 {
+		evictCache(mirror)
+	}
+
+	return mirror, lock.unlock
+}
+
+// evictCache removes the least recently used mirrors until the cache fits into its maximum size,
+// mirrors that are in use by other BuildRuns and the given mirror are kept
+func evictCache(keep string) {
+	type cachedMirror struct {
+		path   string
+		size   int64
+		usedAt time.Time
+	}
+
+	entries, err := os.ReadDir(flagValues.cacheDir)
+	if err != nil {
+		log.Printf("Failed to read the Git cache: %v\n", err)
+		return
+	}
+
+	var mirrors []cachedMirror
+	var total int64
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), ".git") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(flagValues.cacheDir, entry.Name())
+		size := directorySize(path)
+		total += size
+		mirrors = append(mirrors, cachedMirror{path: path, size: size, usedAt: info.ModTime()})
+	}
+
+	sort.Slice(mirrors, func(i, j int) bool {
+		return mirrors[i].usedAt.Before(mirrors[j].usedAt)
+	})
+
+	for _, mirror := range mirrors {
+		if total <= flagValues.cacheMaxSize {
+			return
+		}
+
+		if mirror.path == keep {
+			continue
+		}
+
+		lock, err := lockCache(mirror.path+".lock", false)
+		if err != nil {
+			continue
+		}
+
+		log.Printf("Evicting %s from the Git cache\n", mirror.path)
+		if err := os.RemoveAll(mirror.path); err == nil {
+			total -= mirror.size
+		}
+
+		lock.unlock()
+	}
+}
+
+func directorySize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info, err := entry.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
@@ -0,0 +1,217 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	shpgit "github.com/shipwright-io/build/pkg/git"
+	"github.com/shipwright-io/build/pkg/util"
+)
+
+// The keys of the source secret for short-lived tokens, they match the ones documented for the Build API
+const (
+	secretGitHubAppID             = "github-app-id"
+	secretGitHubAppInstallationID = "github-app-installation-id"
+	secretGitHubAppPrivateKey     = "github-app-private-key"
+	secretGitHubAPIURL            = "github-api-url"
+	secretToken                   = "token"
+	secretTokenUsername           = "username"
+	secretTokenExpiresAt          = "expires-at"
+)
+
+// defaultTokenUsername is used for access tokens without username, GitLab accepts any username together with
+// a project, group or personal access token
+const defaultTokenUsername = "oauth2"
+
+// basicAuthCredentials returns the username and password for a HTTPS repository. For a GitHub App, the password
+// is an installation token that is obtained just in time, so that no long-lived token needs to be stored.
+func basicAuthCredentials(ctx context.Context, secretPath string, credType credentialType) (string, string, error) {
+	switch credType {
+	case typeGitHubApp:
+		token, err := gitHubAppToken(ctx, secretPath)
+		if err != nil {
+			return "", "", tokenError(err.Error())
+		}
+
+		log.Printf("Obtained an installation token of GitHub App %s that expires at %s\n", readSecretValue(secretPath, secretGitHubAppID), token.ExpiresAt.Format(time.RFC3339))
+		return shpgit.GitHubAppTokenUsername, token.Token, nil
+
+	case typeAccessToken:
+		if expiresAt := readSecretValue(secretPath, secretTokenExpiresAt); expiresAt != "" {
+			expiry, err := parseExpiry(expiresAt)
+			if err != nil {
+				return "", "", tokenError(err.Error())
+			}
+
+			if time.Now().After(expiry) {
+				return "", "", tokenError(fmt.Sprintf("the access token expired at %s", expiry.Format(time.RFC3339)))
+			}
+
+			log.Printf("Using an access token that expires at %s\n", expiry.Format(time.RFC3339))
+		}
+
+		username := readSecretValue(secretPath, secretTokenUsername)
+		if username == "" {
+			username = defaultTokenUsername
+		}
+
+		return username, readSecretValue(secretPath, secretToken), nil
+
+	default:
+		username, err := os.ReadFile(filepath.Join(secretPath, "username"))
+		if err != nil {
+			return "", "", err
+		}
+
+		password, err := os.ReadFile(filepath.Join(secretPath, "password"))
+		if err != nil {
+			return "", "", err
+		}
+
+		return string(username), string(password), nil
+	}
+}
+
+func gitHubAppToken(ctx context.Context, secretPath string) (*shpgit.AccessToken, error) {
+	for _, key := range []string{secretGitHubAppID, secretGitHubAppInstallationID, secretGitHubAppPrivateKey} {
+		if !hasFile(secretPath, key) {
+			return nil, fmt.Errorf("the secret has no %s", key)
+		}
+	}
+
+	privateKey, err := os.ReadFile(filepath.Join(secretPath, secretGitHubAppPrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := readSecretValue(secretPath, secretGitHubAPIURL)
+	if apiURL == "" {
+		apiURL = shpgit.GitHubAPIURL
+	}
+
+	// the API of GitHub Enterprise Server may use a certificate of a custom CA
+	rootCAs, err := util.RootCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if rootCAs != nil {
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	return shpgit.GitHubAppInstallationToken(ctx, &http.Client{Transport: transport}, apiURL,
+		readSecretValue(secretPath, secretGitHubAppID), readSecretValue(secretPath, secretGitHubAppInstallationID), privateKey)
+}
+
+// storeCredentials writes the username and password for the repository into a file of the store credential
+// helper, and returns the name of the file
+func storeCredentials(repoURL *url.URL, username string, password string) (string, error) {
+	credentialURL := *repoURL
+	credentialURL.User = url.UserPassword(username, password)
+
+	credHelperFile, err := os.CreateTemp(os.TempDir(), "cred-helper-file")
+	if err != nil {
+		return "", err
+	}
+
+	defer credHelperFile.Close()
+
+	if err := os.WriteFile(credHelperFile.Name(), []byte(credentialURL.String()), 0400); err != nil {
+		return "", err
+	}
+
+	return credHelperFile.Name(), nil
+}
+
+// urlCredentials returns the Git configuration that uses the secret of a <prefix>=<path> argument for the URLs
+// that start with the prefix, and the file of the credential helper. The helpers of the clone secret are reset
+// for these URLs, so that the credentials of one Git host are not sent to another one.
+func urlCredentials(ctx context.Context, urlSecretPath string) ([]string, string, error) {
+	separator := strings.LastIndex(urlSecretPath, "=")
+	if separator <= 0 || separator == len(urlSecretPath)-1 {
+		return nil, "", &ExitError{Code: 100, Message: fmt.Sprintf("the 'url-secret-path' argument %q is not in the form <prefix>=<path>", urlSecretPath)}
+	}
+
+	prefix, secretPath := urlSecretPath[:separator], urlSecretPath[separator+1:]
+
+	credType, err := checkCredentials(secretPath, prefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if credType == typePrivateKey {
+		return nil, "", &ExitError{
+			Code:    110,
+			Message: fmt.Sprintf("The secret for %s contains a SSH private key, only the clone secret can contain one", prefix),
+			Reason:  shpgit.AuthUnexpectedSSH,
+		}
+	}
+
+	prefixURL, err := url.Parse(prefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	username, password, err := basicAuthCredentials(ctx, secretPath, credType)
+	if err != nil {
+		return nil, "", err
+	}
+
+	credHelperFile, err := storeCredentials(prefixURL, username, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return []string{
+		"-c", fmt.Sprintf("credential.%s.helper=", prefix),
+		"-c", fmt.Sprintf("credential.%s.helper=store --file %s", prefix, credHelperFile),
+	}, credHelperFile, nil
+}
+
+// parseExpiry parses the expiry of an access token, which is either a timestamp or a date as GitLab reports it
+func parseExpiry(value string) (time.Time, error) {
+	if expiry, err := time.Parse(time.RFC3339, value); err == nil {
+		return expiry, nil
+	}
+
+	// GitLab tokens expire at the end of the given day
+	expiry, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("the %s of the access token is neither a RFC 3339 timestamp nor a date: %q", secretTokenExpiresAt, value)
+	}
+
+	return expiry.AddDate(0, 0, 1), nil
+}
+
+// readSecretValue returns the trimmed value of a key of the source secret, or an empty string if the secret does not have it
+func readSecretValue(secretPath string, key string) string {
+	data, err := os.ReadFile(filepath.Join(secretPath, key))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+func tokenError(detail string) error {
+	return &ExitError{
+		Code:    110,
+		Message: fmt.Sprintf("fatal: failed to obtain an access token: %s", detail),
+		Reason:  shpgit.AuthTokenFailed,
+	}
+}
@@ -13,8 +13,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	shpgit "github.com/shipwright-io/build/pkg/git"
 	"github.com/shipwright-io/build/pkg/util"
@@ -27,6 +31,8 @@ const (
 	typeUndef credentialType = iota
 	typePrivateKey
 	typeUsernamePassword
+	typeGitHubApp
+	typeAccessToken
 )
 
 var (
@@ -54,19 +60,44 @@ type settings struct {
 	help                      bool
 	url                       string
 	revision                  string
+	refspec                   string
 	depth                     uint
+	filter                    string
+	sparseCheckout            []string
+	submodules                bool
+	submodulePaths            []string
+	submoduleDepth            uint
+	lfs                       bool
+	lfsInclude                []string
+	lfsExclude                []string
 	target                    string
 	resultFileCommitSha       string
 	resultFileCommitAuthor    string
 	resultFileBranchName      string
 	resultFileSourceTimestamp string
+	resultFileCommitSigner    string
+	resultFileFetchedRef      string
+	resultFileCommitter       string
+	resultFileCommitterDate   string
+	resultFileCommitSubject   string
+	resultFileTags            string
+	resultFileDescribe        string
+	resultFileRemoteURL       string
 	secretPath                string
+	knownHostsFile            string
+	retries                   uint
+	retryDelay                time.Duration
+	strictHostKeyChecking     bool
+	urlSecretPaths            []string
 	skipValidation            bool
 	gitURLRewrite             bool
+	verifySecretPath          string
 	resultFileErrorMessage    string
 	resultFileErrorReason     string
 	verbose                   bool
 	showListing               bool
+	cacheDir                  string
+	cacheMaxSize              int64
 }
 
 var flagValues settings
@@ -86,12 +117,25 @@ func init() {
 	// depends on the respective use case.
 	pflag.StringVar(&flagValues.url, "url", "", "The URL of the Git repository")
 	pflag.StringVar(&flagValues.revision, "revision", "", "The revision of the Git repository to be cloned. Optional, defaults to the default branch.")
+	pflag.StringVar(&flagValues.refspec, "refspec", "", "A refspec to fetch and check out instead of a branch, for example refs/pull/42/head. Optional.")
 	pflag.StringVar(&flagValues.target, "target", "", "The target directory of the clone operation")
 	pflag.StringVar(&flagValues.resultFileCommitSha, "result-file-commit-sha", "", "A file to write the commit sha to.")
 	pflag.StringVar(&flagValues.resultFileCommitAuthor, "result-file-commit-author", "", "A file to write the commit author to.")
 	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp to.")
 	pflag.StringVar(&flagValues.resultFileBranchName, "result-file-branch-name", "", "A file to write the branch name to.")
+	pflag.StringVar(&flagValues.resultFileFetchedRef, "result-file-fetched-ref", "", "A file to write the fetched reference to.")
+	pflag.StringVar(&flagValues.resultFileCommitSigner, "result-file-commit-signer", "", "A file to write the signer of the verified commit or tag to.")
+	pflag.StringVar(&flagValues.resultFileCommitter, "result-file-committer", "", "A file to write the committer to.")
+	pflag.StringVar(&flagValues.resultFileCommitterDate, "result-file-committer-date", "", "A file to write the committer date to, in seconds since the epoch.")
+	pflag.StringVar(&flagValues.resultFileCommitSubject, "result-file-commit-subject", "", "A file to write the subject of the commit message to.")
+	pflag.StringVar(&flagValues.resultFileTags, "result-file-tags", "", "A file to write the comma-separated tags that point at the commit to.")
+	pflag.StringVar(&flagValues.resultFileDescribe, "result-file-describe", "", "A file to write the output of git describe to.")
+	pflag.StringVar(&flagValues.resultFileRemoteURL, "result-file-remote-url", "", "A file to write the URL of the repository without credentials to.")
 	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication. Or a SSH private key and optionally a known hosts file. Optional.")
+	pflag.StringVar(&flagValues.knownHostsFile, "known-hosts-file", "", "A known hosts file of the cluster that is merged with the known hosts file of the secret. Optional.")
+	pflag.BoolVar(&flagValues.strictHostKeyChecking, "strict-host-key-checking", false, "Fail instead of accepting unknown SSH host keys if neither the secret nor the cluster provide known hosts")
+	pflag.StringArrayVar(&flagValues.urlSecretPaths, "url-secret-path", nil, "A URL prefix and a directory that contains a secret for the repositories with URLs that start with it, in the form <prefix>=<path>. The secret holds credentials for HTTPS. Can be specified multiple times.")
+	pflag.StringVar(&flagValues.verifySecretPath, "verify-secret-path", "", "A directory that contains the trusted GPG public keys, SSH allowed signers, or gitsign identities to verify the signature of the commit or tag with. Optional.")
 
 	// Flags with paths for writing error related information
 	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to.")
@@ -102,6 +146,27 @@ func init() {
 	// for (in the context of Shipwright build).
 	pflag.UintVar(&flagValues.depth, "depth", 1, "Create a shallow clone based on the given depth")
 
+	// Optional flags to reduce the amount of data that is fetched and checked out for large
+	// repositories, using a partial clone and a cone mode sparse checkout.
+	pflag.StringVar(&flagValues.filter, "filter", "", "Create a partial clone using the given filter, for example blob:none or tree:0")
+	pflag.StringArrayVar(&flagValues.sparseCheckout, "sparse-checkout", nil, "Only check out the given directory using a cone mode sparse checkout, can be specified multiple times")
+
+	// Optional flags to control which submodules and Git LFS files are fetched
+	pflag.BoolVar(&flagValues.submodules, "submodules", true, "Initialize the submodules of the repository")
+	pflag.StringArrayVar(&flagValues.submodulePaths, "submodule-path", nil, "Only initialize the submodule with the given path, can be specified multiple times")
+	pflag.UintVar(&flagValues.submoduleDepth, "submodule-depth", 0, "Limit the recursion into nested submodules to the given depth, 0 means no limit")
+	pflag.BoolVar(&flagValues.lfs, "lfs", true, "Fetch the Git LFS files of the repository")
+	pflag.StringArrayVar(&flagValues.lfsInclude, "lfs-include", nil, "Only fetch the Git LFS files matching the given pattern, can be specified multiple times")
+	pflag.StringArrayVar(&flagValues.lfsExclude, "lfs-exclude", nil, "Do not fetch the Git LFS files matching the given pattern, can be specified multiple times")
+
+	// Optional flags to use a persistent cache of the Git objects that is shared across builds
+	pflag.StringVar(&flagValues.cacheDir, "cache-dir", "", "A directory that holds mirrors of the repositories, clones only fetch the objects that the mirror does not have. Optional.")
+	pflag.Int64Var(&flagValues.cacheMaxSize, "cache-max-size", 0, "The maximum size of the cache directory in bytes, the least recently used mirrors are evicted once it is exceeded, 0 means no limit")
+
+	// Optional flags to retry a clone that failed with a transient error
+	pflag.UintVar(&flagValues.retries, "retries", 3, "The number of times a clone is retried after a transient error, like a DNS failure, a reset connection, or an error of the Git service, 0 disables retries")
+	pflag.DurationVar(&flagValues.retryDelay, "retry-delay", time.Second, "The delay before the first retry, it doubles with every further retry")
+
 	// Mostly internal flag
 	pflag.BoolVar(&flagValues.skipValidation, "skip-validation", false, "skip pre-requisite validation")
 	pflag.BoolVar(&flagValues.gitURLRewrite, "git-url-rewrite", false, "set Git config to use url-insteadOf setting based on Git repository URL")
@@ -128,7 +193,7 @@ func main() {
 
 // Execute performs flag parsing, input validation and the Git clone
 func Execute(ctx context.Context) error {
-	flagValues = settings{depth: 1}
+	flagValues = settings{depth: 1, submodules: true, lfs: true, retries: 3, retryDelay: time.Second}
 	pflag.Parse()
 
 	if val, ok := os.LookupEnv("GIT_SHOW_LISTING"); ok {
@@ -176,10 +241,25 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
-	if err := clone(ctx); err != nil {
+	if err := cloneWithRetries(ctx); err != nil {
 		return err
 	}
 
+	if flagValues.verifySecretPath != "" {
+		signer, err := verifySignature(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Verified the signature of %s, signed by %s\n", displayURL, signer)
+
+		if flagValues.resultFileCommitSigner != "" {
+			if err := os.WriteFile(flagValues.resultFileCommitSigner, []byte(signer), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
 	if flagValues.showListing {
 		// ignore any errors when walking through the file system, the listing is only for informational purposes
 		_ = util.ListFiles(log.Writer(), flagValues.target)
@@ -202,7 +282,7 @@ func runGitClone(ctx context.Context) error {
 			return err
 		}
 
-		if err = os.WriteFile(flagValues.resultFileCommitAuthor, []byte(output), 0644); err != nil {
+		if err = os.WriteFile(flagValues.resultFileCommitAuthor, []byte(truncateResult(output)), 0644); err != nil {
 			return err
 		}
 	}
@@ -218,7 +298,51 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
-	if strings.TrimSpace(flagValues.revision) == "" && strings.TrimSpace(flagValues.resultFileBranchName) != "" {
+	// further details of the commit, which are formatted by git log
+	for _, commitResult := range []struct{ file, format string }{
+		{flagValues.resultFileCommitter, "%cn"},
+		{flagValues.resultFileCommitterDate, "%ct"},
+		{flagValues.resultFileCommitSubject, "%s"},
+	} {
+		if commitResult.file == "" {
+			continue
+		}
+
+		output, err := git(ctx, "-C", flagValues.target, "log", "-1", "--pretty=format:"+commitResult.format)
+		if err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(commitResult.file, []byte(truncateResult(output)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileDescribe != "" {
+		// a shallow clone may not contain any tag, the abbreviated commit sha is used then
+		output, err := git(ctx, "-C", flagValues.target, "describe", "--tags", "--always")
+		if err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(flagValues.resultFileDescribe, []byte(truncateResult(output)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileRemoteURL != "" {
+		if err := os.WriteFile(flagValues.resultFileRemoteURL, []byte(remoteURL()), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.refspec != "" && flagValues.resultFileFetchedRef != "" {
+		if err := os.WriteFile(flagValues.resultFileFetchedRef, []byte(fetchedRef(flagValues.refspec)), 0644); err != nil {
+			return err
+		}
+	}
+
+	if strings.TrimSpace(flagValues.revision) == "" && flagValues.refspec == "" && strings.TrimSpace(flagValues.resultFileBranchName) != "" {
 		output, err := git(ctx, "-C", flagValues.target, "rev-parse", "--abbrev-ref", "HEAD")
 		if err != nil {
 			return err
@@ -278,7 +402,7 @@ func clone(ctx context.Context) error {
 		cloneArgs = append(cloneArgs, "--no-tags")
 	}
 
-	var commitSha string
+	var checkoutRevision string
 	switch {
 	case useRevisionFlag && fullCommitShaRegex.MatchString(flagValues.revision):
 		// we can pass the commit SHA directly to `git clone --revision` and can honor the depth.
@@ -290,7 +414,7 @@ func clone(ctx context.Context) error {
 
 	case commitShaRegEx.MatchString(flagValues.revision):
 		// for a short commit SHA or when --revision is not supported, we must do a full `git clone --no-checkout` and later a checkout. For this, we cannot honor depth.
-		commitSha = flagValues.revision
+		checkoutRevision = flagValues.revision
 		cloneArgs = append(cloneArgs, "--no-checkout")
 
 	default:
@@ -305,9 +429,19 @@ func clone(ctx context.Context) error {
 		}
 	}
 
+	if flagValues.filter != "" {
+		cloneArgs = append(cloneArgs, "--filter", flagValues.filter)
+	}
+
+	// for a sparse checkout, the sparse checkout patterns must be set before the checkout
+	sparseCheckout := len(flagValues.sparseCheckout) > 0
+	if sparseCheckout && checkoutRevision == "" {
+		cloneArgs = append(cloneArgs, "--no-checkout")
+	}
+
 	var addtlGitArgs []string
 	if flagValues.secretPath != "" {
-		credType, err := checkCredentials()
+		credType, err := checkCredentials(flagValues.secretPath, flagValues.url)
 		if err != nil {
 			return err
 		}
@@ -340,13 +474,30 @@ func clone(ctx context.Context) error {
 				"-i", sshPrivateKeyFile.Name(),
 			}
 
-			var knownHostsFile = filepath.Join(flagValues.secretPath, "known_hosts")
-			if hasFile(knownHostsFile) {
+			// the known hosts of the secret are merged with the ones of the cluster
+			var knownHostsFiles []string
+			for _, knownHostsFile := range []string{filepath.Join(flagValues.secretPath, "known_hosts"), flagValues.knownHostsFile} {
+				if knownHostsFile != "" && hasFile(knownHostsFile) {
+					knownHostsFiles = append(knownHostsFiles, knownHostsFile)
+				}
+			}
+
+			switch {
+			case len(knownHostsFiles) > 0:
 				sshCmd = append(sshCmd,
+					"-o", "StrictHostKeyChecking=yes",
 					"-o", "GlobalKnownHostsFile=/dev/null",
-					"-o", fmt.Sprintf("UserKnownHostsFile=%s", knownHostsFile),
+					"-o", fmt.Sprintf("UserKnownHostsFile='%s'", strings.Join(knownHostsFiles, " ")),
 				)
-			} else {
+
+			case flagValues.strictHostKeyChecking:
+				return &ExitError{
+					Code:    110,
+					Message: fmt.Sprintf("fatal: host key verification failed for %s, strict host key checking requires a known_hosts file in the secret or known hosts of the cluster", displayURL),
+					Reason:  shpgit.SSHHostKeyUnverified,
+				}
+
+			default:
 				sshCmd = append(sshCmd,
 					"-o", "StrictHostKeyChecking=accept-new",
 				)
@@ -389,67 +540,139 @@ func clone(ctx context.Context) error {
 				}
 			}
 
-		case typeUsernamePassword:
+		case typeUsernamePassword, typeGitHubApp, typeAccessToken:
 			repoURL, err := url.Parse(flagValues.url)
 			if err != nil {
 				return err
 			}
 
-			username, err := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
+			username, password, err := basicAuthCredentials(ctx, flagValues.secretPath, credType)
 			if err != nil {
 				return err
 			}
 
-			password, err := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
+			credHelperFile, err := storeCredentials(repoURL, username, password)
 			if err != nil {
 				return err
 			}
 
-			repoURL.User = url.UserPassword(string(username), string(password))
-
-			credHelperFile, err := os.CreateTemp(os.TempDir(), "cred-helper-file")
-			if err != nil {
-				return err
-			}
-
-			defer os.Remove(credHelperFile.Name())
-
-			if err := os.WriteFile(credHelperFile.Name(), []byte(repoURL.String()), 0400); err != nil {
-				return err
-			}
+			defer os.Remove(credHelperFile)
 
 			addtlGitArgs = append(addtlGitArgs,
 				"-c",
-				fmt.Sprintf("credential.helper=%s", fmt.Sprintf("store --file %s", credHelperFile.Name())),
+				fmt.Sprintf("credential.helper=%s", fmt.Sprintf("store --file %s", credHelperFile)),
 			)
 		}
 	}
 
-	cloneArgs = append(cloneArgs, addtlGitArgs...)
-	cloneArgs = append(cloneArgs, "--", flagValues.url, flagValues.target)
-	if _, err := git(ctx, cloneArgs...); err != nil {
+	// the credentials for other URLs, like the ones of submodules on other Git hosts, are
+	// configured after the clone secret so that they take precedence for their URLs
+	for _, urlSecretPath := range flagValues.urlSecretPaths {
+		credentialArgs, credHelperFile, err := urlCredentials(ctx, urlSecretPath)
+		if err != nil {
+			return err
+		}
+
+		defer os.Remove(credHelperFile)
+
+		addtlGitArgs = append(addtlGitArgs, credentialArgs...)
+	}
+
+	// Git only accepts a single file of trusted certificates, a custom CA bundle is
+	// therefore combined with the system roots into a temporary file
+	caBundle, err := util.CABundleWithSystemRoots()
+	if err != nil {
 		return err
 	}
 
-	if commitSha != "" {
-		if _, err := git(ctx, "-C", flagValues.target, "checkout", commitSha); err != nil {
+	if caBundle != nil {
+		caBundleFile, err := os.CreateTemp(os.TempDir(), "ca-bundle")
+		if err != nil {
 			return err
 		}
+
+		defer os.Remove(caBundleFile.Name())
+
+		if err := os.WriteFile(caBundleFile.Name(), caBundle, 0400); err != nil {
+			return err
+		}
+
+		addtlGitArgs = append(addtlGitArgs,
+			"-c",
+			fmt.Sprintf("http.sslCAInfo=%s", caBundleFile.Name()),
+		)
 	}
 
-	submoduleArgs := []string{"-C", flagValues.target}
-	submoduleArgs = append(submoduleArgs, addtlGitArgs...)
-	submoduleArgs = append(submoduleArgs, "submodule", "update", "--init", "--recursive")
-	if useDepthForSubmodule && flagValues.depth > 0 {
-		submoduleArgs = append(submoduleArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+	// the Git LFS settings are passed to all commands that check out files, including submodules
+	addtlGitArgs = append(addtlGitArgs, lfsArgs()...)
+
+	// objects that the cache holds already are copied from its mirror instead of being fetched
+	var reference string
+	releaseCache := func() {}
+	if flagValues.cacheDir != "" {
+		reference, releaseCache = prepareCache(ctx, addtlGitArgs)
 	}
 
-	if _, err := git(ctx, submoduleArgs...); err != nil {
-		return err
+	if flagValues.refspec != "" {
+		err := fetchRefspec(ctx, addtlGitArgs, reference)
+		releaseCache()
+		if err != nil {
+			return err
+		}
+
+		// check out the fetched reference, or the commit of it that was requested
+		checkoutRevision = "FETCH_HEAD"
+		if flagValues.revision != "" {
+			checkoutRevision = flagValues.revision
+		}
+	} else {
+		if reference != "" {
+			cloneArgs = append(cloneArgs, "--reference", reference, "--dissociate")
+		}
+
+		cloneArgs = append(cloneArgs, addtlGitArgs...)
+		cloneArgs = append(cloneArgs, "--", flagValues.url, flagValues.target)
+		_, err := git(ctx, cloneArgs...)
+		releaseCache()
+		if err != nil {
+			return err
+		}
+	}
+
+	if sparseCheckout {
+		sparseCheckoutArgs := []string{"-C", flagValues.target, "sparse-checkout", "set", "--cone", "--"}
+		sparseCheckoutArgs = append(sparseCheckoutArgs, flagValues.sparseCheckout...)
+		if _, err := git(ctx, sparseCheckoutArgs...); err != nil {
+			return err
+		}
+	}
+
+	if checkoutRevision != "" || sparseCheckout {
+		// the checkout of a partial clone fetches the missing objects, which requires the credentials
+		checkoutArgs := []string{"-C", flagValues.target}
+		checkoutArgs = append(checkoutArgs, addtlGitArgs...)
+		checkoutArgs = append(checkoutArgs, "checkout")
+		if checkoutRevision != "" {
+			checkoutArgs = append(checkoutArgs, checkoutRevision)
+		}
+
+		if _, err := git(ctx, checkoutArgs...); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.submodules {
+		if err := updateSubmodules(ctx, flagValues.target, flagValues.submodulePaths, flagValues.submoduleDepth, addtlGitArgs); err != nil {
+			return err
+		}
 	}
 
 	revision := flagValues.revision
-	if revision == "" {
+	switch {
+	case flagValues.refspec != "" && revision == "":
+		revision = fetchedRef(flagValues.refspec)
+
+	case revision == "":
 		// user requested to clone the default branch, determine the branch name
 		refParse, err := git(ctx, "-C", flagValues.target, "rev-parse", "--abbrev-ref", "HEAD")
 		if err != nil {
@@ -459,6 +682,13 @@ func clone(ctx context.Context) error {
 		revision = strings.TrimRight(refParse, "\n")
 	}
 
+	// the clone does not fetch all tags, the tags are therefore listed while the credentials are available
+	if flagValues.resultFileTags != "" {
+		if err := writeTagsResult(ctx, addtlGitArgs); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("Successfully loaded %s (%s) into %s\n",
 		displayURL,
 		revision,
@@ -468,6 +698,213 @@ func clone(ctx context.Context) error {
 	return nil
 }
 
+// fetchRefspec initializes an empty repository in the target directory and fetches the refspec into it,
+// this is used for references that a clone does not fetch, like pull request or Gerrit change refs. The
+// fetched commit is available as FETCH_HEAD afterwards.
+func fetchRefspec(ctx context.Context, addtlGitArgs []string, reference string) error {
+	if _, err := git(ctx, "init", "--quiet", flagValues.target); err != nil {
+		return err
+	}
+
+	// like a clone with --reference and --dissociate, the objects of the reference are borrowed
+	// during the fetch and copied afterwards
+	alternates := filepath.Join(flagValues.target, ".git", "objects", "info", "alternates")
+	if reference != "" {
+		if err := os.WriteFile(alternates, []byte(filepath.Join(reference, "objects")+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+
+	if _, err := git(ctx, "-C", flagValues.target, "remote", "add", "origin", flagValues.url); err != nil {
+		return err
+	}
+
+	fetchArgs := []string{"-C", flagValues.target}
+	fetchArgs = append(fetchArgs, addtlGitArgs...)
+	fetchArgs = append(fetchArgs, "fetch", "--quiet", "--no-tags")
+
+	if flagValues.depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+	}
+
+	if flagValues.filter != "" {
+		fetchArgs = append(fetchArgs, "--filter", flagValues.filter)
+	}
+
+	fetchArgs = append(fetchArgs, "origin", flagValues.refspec)
+	if _, err := git(ctx, fetchArgs...); err != nil {
+		return err
+	}
+
+	if reference != "" {
+		// the repack only keeps objects that are reachable from a ref, which FETCH_HEAD is not
+		const keepRef = "refs/shipwright/fetched"
+		if _, err := git(ctx, "-C", flagValues.target, "update-ref", keepRef, "FETCH_HEAD"); err != nil {
+			return err
+		}
+
+		if _, err := git(ctx, "-C", flagValues.target, "repack", "-a", "-d", "-q"); err != nil {
+			return err
+		}
+
+		if err := os.Remove(alternates); err != nil {
+			return err
+		}
+
+		_, err := git(ctx, "-C", flagValues.target, "update-ref", "-d", keepRef)
+		return err
+	}
+
+	return nil
+}
+
+// writeTagsResult writes the comma-separated tags of the remote repository that point at the checked out commit,
+// the tags are informational only and an empty result is written if they cannot be listed
+func writeTagsResult(ctx context.Context, addtlGitArgs []string) error {
+	head, err := git(ctx, "-C", flagValues.target, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	lsRemoteArgs := []string{"-C", flagValues.target}
+	lsRemoteArgs = append(lsRemoteArgs, addtlGitArgs...)
+	lsRemoteArgs = append(lsRemoteArgs, "ls-remote", "--tags", "origin")
+	output, err := git(ctx, lsRemoteArgs...)
+	if err != nil {
+		log.Printf("Warning: could not list the tags of %s: %s\n", displayURL, err.Error())
+		return os.WriteFile(flagValues.resultFileTags, []byte{}, 0644)
+	}
+
+	// an annotated tag is listed twice, the peeled entry with the ^{} suffix holds the commit
+	var tags []string
+	for _, line := range strings.Split(output, "\n") {
+		commitSha, ref, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if !found || commitSha != head {
+			continue
+		}
+
+		tag := strings.TrimSuffix(strings.TrimPrefix(ref, "refs/tags/"), "^{}")
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Strings(tags)
+
+	// only complete tags are written, the remaining ones are omitted once the result exceeds its threshold
+	var result string
+	for _, tag := range tags {
+		next := tag
+		if result != "" {
+			next = result + "," + tag
+		}
+
+		if len(next) > resultLengthThreshold {
+			log.Printf("Warning: omitted tags of %s, the result is limited to %d bytes\n", displayURL, resultLengthThreshold)
+			break
+		}
+
+		result = next
+	}
+
+	return os.WriteFile(flagValues.resultFileTags, []byte(result), 0644)
+}
+
+// resultLengthThreshold is the maximum length of results that contain free text, the termination message
+// of a step that holds all its results is limited to 4 KB
+const resultLengthThreshold = 256
+
+// truncateResult shortens the value to the threshold of results without breaking a multi-byte character
+func truncateResult(value string) string {
+	if len(value) <= resultLengthThreshold {
+		return value
+	}
+
+	value = value[:resultLengthThreshold-3]
+	for !utf8.ValidString(value) {
+		value = value[:len(value)-1]
+	}
+
+	return value + "..."
+}
+
+// fetchedRef returns the source reference of a refspec, for example refs/pull/42/head for
+// +refs/pull/42/head:refs/remotes/origin/pr/42
+func fetchedRef(refspec string) string {
+	source, _, _ := strings.Cut(strings.TrimPrefix(refspec, "+"), ":")
+	return source
+}
+
+// updateSubmodules initializes the submodules of the repository in the given directory, optionally
+// restricted to the given paths. A depth of zero initializes all nested submodules recursively,
+// otherwise the recursion stops once the depth is reached.
+func updateSubmodules(ctx context.Context, dir string, paths []string, depth uint, addtlGitArgs []string) error {
+	submoduleArgs := []string{"-c", fmt.Sprintf("safe.directory=%s", dir), "-C", dir}
+	submoduleArgs = append(submoduleArgs, addtlGitArgs...)
+	submoduleArgs = append(submoduleArgs, "submodule", "update", "--init")
+	if depth == 0 {
+		submoduleArgs = append(submoduleArgs, "--recursive")
+	}
+
+	if useDepthForSubmodule && flagValues.depth > 0 {
+		submoduleArgs = append(submoduleArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+	}
+
+	if len(paths) > 0 {
+		submoduleArgs = append(submoduleArgs, "--")
+		submoduleArgs = append(submoduleArgs, paths...)
+	}
+
+	if _, err := git(ctx, submoduleArgs...); err != nil {
+		return err
+	}
+
+	if depth <= 1 {
+		return nil
+	}
+
+	// only the submodules that were initialized above are visited
+	out, err := git(ctx, "-c", fmt.Sprintf("safe.directory=%s", dir), "-C", dir, "submodule", "foreach", "--quiet", "echo $sm_path")
+	if err != nil {
+		return err
+	}
+
+	for _, submodulePath := range strings.Split(strings.TrimSpace(out), "\n") {
+		if submodulePath == "" {
+			continue
+		}
+
+		if err := updateSubmodules(ctx, filepath.Join(dir, submodulePath), nil, depth-1, addtlGitArgs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lfsArgs returns the Git configuration to skip the download of Git LFS files, or to restrict it to
+// the files matching the include and exclude patterns
+func lfsArgs() []string {
+	if !flagValues.lfs {
+		// like `git lfs install --skip-smudge`, the files are checked out as pointer files
+		return []string{
+			"-c", "filter.lfs.smudge=git-lfs smudge --skip -- %f",
+			"-c", "filter.lfs.process=git-lfs filter-process --skip",
+		}
+	}
+
+	var args []string
+	if len(flagValues.lfsInclude) > 0 {
+		args = append(args, "-c", fmt.Sprintf("lfs.fetchinclude=%s", strings.Join(flagValues.lfsInclude, ",")))
+	}
+
+	if len(flagValues.lfsExclude) > 0 {
+		args = append(args, "-c", fmt.Sprintf("lfs.fetchexclude=%s", strings.Join(flagValues.lfsExclude, ",")))
+	}
+
+	return args
+}
+
 func git(ctx context.Context, args ...string) (string, error) {
 	fullArgs := []string{
 		"-c",
@@ -502,6 +939,16 @@ func git(ctx context.Context, args ...string) (string, error) {
 				Message: output,
 				Cause:   err,
 			}
+
+			// SSH reports the failed verification without the prefix of Git, which is what the error classification relies on
+			if strings.Contains(output, "Host key verification failed") {
+				err = &ExitError{
+					Code:    terr.ExitCode(),
+					Message: fmt.Sprintf("fatal: host key verification failed for %s, the host key is not one of the known hosts\n%s", displayURL, output),
+					Cause:   err,
+					Reason:  shpgit.SSHHostKeyUnverified,
+				}
+			}
 		}
 	}
 
@@ -513,11 +960,11 @@ func hasFile(elem ...string) bool {
 	return !os.IsNotExist(err)
 }
 
-func checkCredentials() (credentialType, error) {
+func checkCredentials(secretPath string, repoURL string) (credentialType, error) {
 	// Checking whether mounted secret is of type `kubernetes.io/ssh-auth`
 	// in which case there is a file called ssh-privatekey
-	hasPrivateKey := hasFile(flagValues.secretPath, "ssh-privatekey")
-	isSSHGitURL := sshGitURLRegEx.MatchString(flagValues.url)
+	hasPrivateKey := hasFile(secretPath, "ssh-privatekey")
+	isSSHGitURL := sshGitURLRegEx.MatchString(repoURL)
 	isGitURLRewriteSet := flagValues.gitURLRewrite
 	switch {
 	case hasPrivateKey && isSSHGitURL:
@@ -541,15 +988,37 @@ func checkCredentials() (credentialType, error) {
 		}
 	}
 
+	// Checking whether mounted secret holds a GitHub App or an access token,
+	// which are both used like a username and password over HTTPS
+	var tokenType = typeUndef
+	switch {
+	case hasFile(secretPath, secretGitHubAppID):
+		tokenType = typeGitHubApp
+	case hasFile(secretPath, secretToken):
+		tokenType = typeAccessToken
+	}
+
+	switch {
+	case tokenType != typeUndef && strings.HasPrefix(repoURL, "https://"):
+		return tokenType, nil
+
+	case tokenType != typeUndef && strings.HasPrefix(repoURL, "http://"):
+		return typeUndef, &ExitError{
+			Code:    110,
+			Message: shpgit.AuthUnexpectedHTTP.ToMessage(),
+			Reason:  shpgit.AuthUnexpectedHTTP,
+		}
+	}
+
 	// Checking whether mounted secret is of type `kubernetes.io/basic-auth`
 	// in which case there need to be the files username and password
-	hasUsername := hasFile(flagValues.secretPath, "username")
-	hasPassword := hasFile(flagValues.secretPath, "password")
+	hasUsername := hasFile(secretPath, "username")
+	hasPassword := hasFile(secretPath, "password")
 	switch {
-	case hasUsername && hasPassword && strings.HasPrefix(flagValues.url, "https://"):
+	case hasUsername && hasPassword && strings.HasPrefix(repoURL, "https://"):
 		return typeUsernamePassword, nil
 
-	case hasUsername && hasPassword && strings.HasPrefix(flagValues.url, "http://"):
+	case hasUsername && hasPassword && strings.HasPrefix(repoURL, "http://"):
 		return typeUndef, &ExitError{
 			Code:    110,
 			Message: shpgit.AuthUnexpectedHTTP.ToMessage(),
@@ -566,7 +1035,7 @@ func checkCredentials() (credentialType, error) {
 
 	return typeUndef, &ExitError{
 		Code:    110,
-		Message: "Unsupported type of credentials provided, either SSH private key or username/password is supported",
+		Message: "Unsupported type of credentials provided, either SSH private key, username/password, GitHub App or access token is supported",
 		Reason:  shpgit.Unknown,
 	}
 }
@@ -590,6 +1059,20 @@ func writeErrorResults(failure *shpgit.ErrorResult) (err error) {
 	return os.WriteFile(flagValues.resultFileErrorReason, []byte(failure.Reason.String()), 0666)
 }
 
+// remoteURL returns the URL of the repository without any inline credentials
+func remoteURL() string {
+	if !strings.HasPrefix(flagValues.url, "http") {
+		return flagValues.url
+	}
+
+	if repoURL, err := url.Parse(flagValues.url); err == nil {
+		repoURL.User = nil
+		return repoURL.String()
+	}
+
+	return flagValues.url
+}
+
 func cleanURL() string {
 	// non HTTP/HTTPS URLs are returned as-is (i.e. Git+SSH URLs)
 	if !strings.HasPrefix(flagValues.url, "http") {
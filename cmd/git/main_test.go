@@ -7,20 +7,32 @@ package main_test
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
 
 	. "github.com/shipwright-io/build/cmd/git"
 	shpgit "github.com/shipwright-io/build/pkg/git"
+	"github.com/shipwright-io/build/pkg/util"
 )
 
 type opts struct {
@@ -57,7 +69,8 @@ func run(o ...runOpts) error {
 	os.Stderr = nil
 	defer func() { os.Stderr = tmp }()
 
-	os.Args = append([]string{"tool", "--skip-validation"}, settings.args...)
+	// retry transient errors without a noticeable backoff, tests that depend on an unreachable network fail fast
+	os.Args = append([]string{"tool", "--skip-validation", "--retry-delay", "1ms"}, settings.args...)
 	return Execute(settings.ctx)
 }
 
@@ -88,6 +101,55 @@ var _ = Describe("Git Resource", func() {
 		Expect(os.WriteFile(path, data, mode)).ToNot(HaveOccurred())
 	}
 
+	// gitIn runs a Git command in the local repository with the given file URL
+	var gitIn = func(repoURL string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", strings.TrimPrefix(repoURL, "file://"), "-c", "user.name=shipwright", "-c", "user.email=shipwright@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		Expect(err).ToNot(HaveOccurred(), string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	// localRepository creates a repository with a commit of the given files and returns its file URL,
+	// the Git configuration is written directly as other tests modify the environment of Git
+	var localRepository = func(files ...string) string {
+		repo, err := os.MkdirTemp(os.TempDir(), "repo")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, repo)
+
+		repoURL := "file://" + repo
+		gitIn(repoURL, "init", "--initial-branch", "main")
+
+		config, err := os.OpenFile(filepath.Join(repo, ".git", "config"), os.O_APPEND|os.O_WRONLY, 0644)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = config.WriteString("[uploadpack]\n\tallowFilter = true\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.Close()).To(Succeed())
+
+		for _, path := range files {
+			Expect(os.MkdirAll(filepath.Dir(filepath.Join(repo, path)), 0755)).To(Succeed())
+			file(filepath.Join(repo, path), 0644, []byte(path))
+		}
+
+		gitIn(repoURL, "add", ".")
+		gitIn(repoURL, "commit", "-m", "initial commit")
+		return repoURL
+	}
+
+	// addSubmodule commits the head of the submodule repository as submodule at the given path
+	var addSubmodule = func(repoURL string, path string, submoduleURL string) {
+		repo := strings.TrimPrefix(repoURL, "file://")
+
+		gitmodules, err := os.OpenFile(filepath.Join(repo, ".gitmodules"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = fmt.Fprintf(gitmodules, "[submodule %q]\n\tpath = %s\n\turl = %s\n", path, path, submoduleURL)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gitmodules.Close()).To(Succeed())
+
+		gitIn(repoURL, "update-index", "--add", "--cacheinfo", "160000,"+gitIn(submoduleURL, "rev-parse", "HEAD")+","+path)
+		gitIn(repoURL, "add", ".gitmodules")
+		gitIn(repoURL, "commit", "-m", "add submodule "+path)
+	}
+
 	Context("validations and error cases", func() {
 		It("should succeed in case the help is requested", func() {
 			Expect(run(withArgs("--help"))).ToNot(HaveOccurred())
@@ -319,6 +381,43 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	// httpsServer serves the bare repositories in the root directory using the smart HTTP protocol over TLS
+	// and returns its URL, requests that the handler answers are not passed on to Git. The certificate of
+	// the server is trusted through the CA bundle.
+	var httpsServer = func(root string, handler func(w http.ResponseWriter, r *http.Request) bool) string {
+		backend := &cgi.Handler{
+			Path: filepath.Join(gitIn("file://"+root, "--exec-path"), "git-http-backend"),
+			Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+		}
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !handler(w, r) {
+				backend.ServeHTTP(w, r)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		caBundle := filepath.Join(root, "ca-bundle.crt")
+		file(caBundle, 0644, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+		GinkgoT().Setenv(util.CABundleFileEnvVar, caBundle)
+
+		// the environment variable takes precedence over the CA bundle that is configured for Git
+		GinkgoT().Setenv("GIT_SSL_CAINFO", "")
+		Expect(os.Unsetenv("GIT_SSL_CAINFO")).To(Succeed())
+
+		return server.URL
+	}
+
+	// requireBasicAuth answers the request with a challenge for basic authentication unless it is authorized
+	var requireBasicAuth = func(w http.ResponseWriter, authorized bool) bool {
+		if !authorized {
+			w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+
+		return !authorized
+	}
+
 	Context("cloning private repositories using basic auth", func() {
 		const exampleRepo = "https://github.com/shipwright-io/sample-nodejs-private"
 
@@ -409,6 +508,508 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("cloning private repositories using short-lived tokens", func() {
+		var repoURL string
+		var apiURL string
+		var privateKey *rsa.PrivateKey
+
+		BeforeEach(func() {
+			root, err := os.MkdirTemp(os.TempDir(), "git-server")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, root)
+
+			gitIn("file://"+root, "clone", "--quiet", "--bare", localRepository("README.md"), "repo.git")
+
+			privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the repository requires an installation token that the stand-in for the GitHub API
+			// creates for a JSON Web Token of App 1234 signed with its key, or an access token
+			apiURL = httpsServer(root, func(w http.ResponseWriter, r *http.Request) bool {
+				if r.URL.Path == "/app/installations/5678/access_tokens" {
+					claims := &jwt.RegisteredClaims{}
+					_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims, func(*jwt.Token) (interface{}, error) {
+						return &privateKey.PublicKey, nil
+					})
+					if err != nil || claims.Issuer != "1234" {
+						w.WriteHeader(http.StatusUnauthorized)
+						fmt.Fprint(w, `{"message":"A JSON web token could not be decoded"}`)
+						return true
+					}
+
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `{"token":"ghs_installationtoken","expires_at":%q}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+					return true
+				}
+
+				username, password, ok := r.BasicAuth()
+				return requireBasicAuth(w, ok && (username == "x-access-token" && password == "ghs_installationtoken" || username == "oauth2" && password == "glpat-token"))
+			})
+
+			repoURL = apiURL + "/repo.git"
+		})
+
+		It("should Git clone a private repository using a GitHub App", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "github-app-id"), 0400, []byte("1234"))
+				file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("5678"))
+				file(filepath.Join(secret, "github-app-private-key"), 0400, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}))
+				file(filepath.Join(secret, "github-api-url"), 0400, []byte(apiURL))
+
+				withTempDir(func(target string) {
+					var buf bytes.Buffer
+					Expect(run(
+						withLogOutput(&buf),
+						withArgs(
+							"--url", repoURL,
+							"--secret-path", secret,
+							"--target", target,
+						),
+					)).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					Expect(buf.String()).To(ContainSubstring("Obtained an installation token of GitHub App 1234 that expires at"))
+					Expect(buf.String()).ToNot(ContainSubstring("ghs_installationtoken"))
+				})
+			})
+		})
+
+		It("should fail when the GitHub App cannot obtain an installation token", func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "github-app-id"), 0400, []byte("1234"))
+				file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("5678"))
+				file(filepath.Join(secret, "github-app-private-key"), 0400, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(otherKey)}))
+				file(filepath.Join(secret, "github-api-url"), 0400, []byte(apiURL))
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", repoURL,
+						"--secret-path", secret,
+						"--target", target,
+					))
+					Expect(err).To(FailWith(shpgit.AuthTokenFailed))
+
+					errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+					Expect(errorResult.Reason.String()).To(Equal(shpgit.AuthTokenFailed.String()))
+				})
+			})
+		})
+
+		It("should Git clone a private repository using an access token", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "token"), 0400, []byte("glpat-token"))
+				file(filepath.Join(secret, "expires-at"), 0400, []byte(time.Now().AddDate(0, 0, 7).Format(time.DateOnly)))
+
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--secret-path", secret,
+						"--target", target,
+					))).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should fail for an expired access token", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "token"), 0400, []byte("glpat-token"))
+				file(filepath.Join(secret, "expires-at"), 0400, []byte("2020-01-01"))
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", repoURL,
+						"--secret-path", secret,
+						"--target", target,
+					))
+					Expect(err).To(FailWith(shpgit.AuthTokenFailed))
+					Expect(err).To(MatchError(ContainSubstring("the access token expired at 2020-01-02T00:00:00Z")))
+				})
+			})
+		})
+
+		It("should fail in case an access token is used in conjunction with HTTP URI", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "token"), 0400, []byte("glpat-token"))
+
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", strings.Replace(repoURL, "https://", "http://", 1),
+						"--secret-path", secret,
+						"--target", target,
+					))).To(FailWith(shpgit.AuthUnexpectedHTTP))
+				})
+			})
+		})
+	})
+
+	Context("cloning repositories with credentials for other URLs", func() {
+		var serverURL string
+
+		BeforeEach(func() {
+			root, err := os.MkdirTemp(os.TempDir(), "git-server")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, root)
+
+			// the repository and its submodule require different credentials
+			serverURL = httpsServer(root, func(w http.ResponseWriter, r *http.Request) bool {
+				username, password, ok := r.BasicAuth()
+				if strings.HasPrefix(r.URL.Path, "/other/") {
+					return requireBasicAuth(w, ok && username == "oauth2" && password == "glpat-token")
+				}
+
+				return requireBasicAuth(w, ok && username == "somebody" && password == "secret")
+			})
+
+			Expect(os.Mkdir(filepath.Join(root, "other"), 0755)).To(Succeed())
+			submoduleURL := localRepository("submodule.txt")
+			gitIn("file://"+root, "clone", "--quiet", "--bare", submoduleURL, "other/submodule.git")
+
+			repoURL := localRepository("README.md")
+			file(filepath.Join(strings.TrimPrefix(repoURL, "file://"), ".gitmodules"), 0644, []byte(fmt.Sprintf("[submodule \"submodule\"]\n\tpath = submodule\n\turl = %s/other/submodule.git\n", serverURL)))
+			gitIn(repoURL, "update-index", "--add", "--cacheinfo", "160000,"+gitIn(submoduleURL, "rev-parse", "HEAD")+",submodule")
+			gitIn(repoURL, "add", ".gitmodules")
+			gitIn(repoURL, "commit", "-m", "add submodule")
+			gitIn("file://"+root, "clone", "--quiet", "--bare", repoURL, "repo.git")
+		})
+
+		var withSecrets = func(f func(secret string, otherSecret string)) {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "username"), 0400, []byte("somebody"))
+				file(filepath.Join(secret, "password"), 0400, []byte("secret"))
+
+				withTempDir(func(otherSecret string) {
+					file(filepath.Join(otherSecret, "token"), 0400, []byte("glpat-token"))
+
+					f(secret, otherSecret)
+				})
+			})
+		}
+
+		It("should authenticate the submodule with the secret of its URL", func() {
+			withSecrets(func(secret string, otherSecret string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", serverURL+"/repo.git",
+						"--secret-path", secret,
+						"--url-secret-path", serverURL+"/other/="+otherSecret,
+						"--target", target,
+					))).To(Succeed())
+
+					Expect(filecontent(filepath.Join(target, "submodule", "submodule.txt"))).To(Equal("submodule.txt"))
+				})
+			})
+		})
+
+		It("should fail to clone the submodule without the secret of its URL", func() {
+			withSecrets(func(secret string, _ string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", serverURL+"/repo.git",
+						"--secret-path", secret,
+						"--target", target,
+					))).To(MatchError(ContainSubstring("other/submodule.git")))
+				})
+			})
+		})
+
+		It("should fail for an argument without a secret path", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", serverURL+"/repo.git",
+					"--url-secret-path", serverURL+"/other/",
+					"--target", target,
+				))).To(MatchError(ContainSubstring("is not in the form <prefix>=<path>")))
+			})
+		})
+	})
+
+	Context("verifying SSH host keys", func() {
+		var repoURL string
+		var knownHosts []byte
+		var clientKey []byte
+
+		// generateKey returns a new ed25519 key as signer and in the OpenSSH format
+		var generateKey = func() (ssh.Signer, []byte) {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			signer, err := ssh.NewSignerFromKey(privateKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			block, err := ssh.MarshalPrivateKey(privateKey, "")
+			Expect(err).ToNot(HaveOccurred())
+
+			return signer, pem.EncodeToMemory(block)
+		}
+
+		BeforeEach(func() {
+			root, err := os.MkdirTemp(os.TempDir(), "git-server")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, root)
+
+			gitIn("file://"+root, "clone", "--quiet", "--bare", localRepository("README.md"), "repo.git")
+
+			// a SSH server that accepts any client key and runs the Git commands on the repositories in the root directory
+			hostKey, _ := generateKey()
+			config := &ssh.ServerConfig{
+				PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) { return nil, nil },
+			}
+			config.AddHostKey(hostKey)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(listener.Close)
+
+			serveChannel := func(channel ssh.Channel, requests <-chan *ssh.Request) {
+				defer channel.Close()
+
+				for request := range requests {
+					var payload struct{ Command string }
+					if request.Type != "exec" || ssh.Unmarshal(request.Payload, &payload) != nil {
+						_ = request.Reply(false, nil)
+						continue
+					}
+
+					_ = request.Reply(true, nil)
+
+					command, repository, _ := strings.Cut(payload.Command, " ")
+					cmd := exec.Command(command, filepath.Join(root, strings.Trim(repository, "'")))
+					cmd.Stdout = channel
+					cmd.Stderr = channel.Stderr()
+					stdin, err := cmd.StdinPipe()
+					if err != nil {
+						return
+					}
+
+					go func() {
+						_, _ = io.Copy(stdin, channel)
+						stdin.Close()
+					}()
+
+					var status uint32
+					if err := cmd.Run(); err != nil {
+						status = 1
+					}
+
+					_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+					return
+				}
+			}
+
+			go func() {
+				for {
+					conn, err := listener.Accept()
+					if err != nil {
+						return
+					}
+
+					go func() {
+						defer conn.Close()
+
+						_, channels, requests, err := ssh.NewServerConn(conn, config)
+						if err != nil {
+							return
+						}
+
+						go ssh.DiscardRequests(requests)
+
+						for newChannel := range channels {
+							channel, requests, err := newChannel.Accept()
+							if err != nil {
+								return
+							}
+
+							go serveChannel(channel, requests)
+						}
+					}()
+				}
+			}()
+
+			address := listener.Addr().(*net.TCPAddr)
+			repoURL = fmt.Sprintf("ssh://git@127.0.0.1:%d/repo.git", address.Port)
+			knownHosts = []byte(fmt.Sprintf("[127.0.0.1]:%d %s", address.Port, ssh.MarshalAuthorizedKey(hostKey.PublicKey())))
+			_, clientKey = generateKey()
+		})
+
+		It("should Git clone using the known hosts of the cluster", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "ssh-privatekey"), 0400, clientKey)
+
+				withTempFile("known_hosts", func(knownHostsFile string) {
+					file(knownHostsFile, 0644, knownHosts)
+
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", repoURL,
+							"--secret-path", secret,
+							"--known-hosts-file", knownHostsFile,
+							"--strict-host-key-checking",
+							"--target", target,
+						))).To(Succeed())
+
+						Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					})
+				})
+			})
+		})
+
+		It("should fail for a host key that is not one of the known hosts", func() {
+			otherHostKey, _ := generateKey()
+
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "ssh-privatekey"), 0400, clientKey)
+				file(filepath.Join(secret, "known_hosts"), 0400, []byte(fmt.Sprintf("%s %s", strings.Fields(string(knownHosts))[0], ssh.MarshalAuthorizedKey(otherHostKey.PublicKey()))))
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", repoURL,
+						"--secret-path", secret,
+						"--target", target,
+					))
+					Expect(err).To(FailWith(shpgit.SSHHostKeyUnverified))
+
+					errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+					Expect(errorResult.Reason.String()).To(Equal(shpgit.SSHHostKeyUnverified.String()))
+				})
+			})
+		})
+
+		It("should fail without known hosts when strict host key checking is required", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "ssh-privatekey"), 0400, clientKey)
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", repoURL,
+						"--secret-path", secret,
+						"--known-hosts-file", filepath.Join(target, "known_hosts"),
+						"--strict-host-key-checking",
+						"--target", target,
+					))
+					Expect(err).To(FailWith(shpgit.SSHHostKeyUnverified))
+
+					errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+					Expect(errorResult.Reason.String()).To(Equal(shpgit.SSHHostKeyUnverified.String()))
+				})
+			})
+		})
+	})
+
+	Context("retrying transient errors", func() {
+		var repoURL string
+		var failures int
+		var failureStatus int
+		var attempts int
+
+		BeforeEach(func() {
+			root, err := os.MkdirTemp(os.TempDir(), "git-server")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, root)
+
+			gitIn("file://"+root, "clone", "--quiet", "--bare", localRepository("README.md"), "repo.git")
+
+			backend := &cgi.Handler{
+				Path: filepath.Join(gitIn("file://"+root, "--exec-path"), "git-http-backend"),
+				Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+			}
+
+			// the server fails the first requests of every attempt with the given status
+			failures, failureStatus, attempts = 0, http.StatusServiceUnavailable, 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/info/refs") {
+					attempts++
+					if attempts <= failures {
+						w.WriteHeader(failureStatus)
+						return
+					}
+				}
+
+				backend.ServeHTTP(w, r)
+			}))
+			DeferCleanup(server.Close)
+
+			repoURL = server.URL + "/repo.git"
+		})
+
+		It("should retry a clone that failed with a server error", func() {
+			failures = 2
+
+			withTempDir(func(target string) {
+				var buf bytes.Buffer
+				Expect(run(
+					withLogOutput(&buf),
+					withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--retry-delay", "10ms",
+					),
+				)).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(attempts).To(Equal(3))
+				Expect(buf.String()).To(ContainSubstring("Attempt 1 of 4 to clone %s\n", repoURL))
+				Expect(buf.String()).To(ContainSubstring("Attempt 2 of 4 to clone %s failed with a transient error, retrying in 20ms", repoURL))
+				Expect(buf.String()).To(ContainSubstring("Attempt 3 of 4 to clone %s\n", repoURL))
+				Expect(buf.String()).To(ContainSubstring("Cloned %s in attempt 3 of 4", repoURL))
+			})
+		})
+
+		It("should give up after the configured number of retries", func() {
+			failures = 10
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--retries", "1",
+					"--retry-delay", "10ms",
+				))).To(MatchError(ContainSubstring("The requested URL returned error: 503")))
+
+				Expect(attempts).To(Equal(2))
+			})
+		})
+
+		It("should not retry a repository that does not exist", func() {
+			failures, failureStatus = 10, http.StatusNotFound
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--retry-delay", "10ms",
+				))
+				Expect(err).To(HaveOccurred())
+
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+
+				Expect(attempts).To(Equal(1))
+			})
+		})
+
+		It("should not retry a failed authentication", func() {
+			failures, failureStatus = 10, http.StatusUnauthorized
+
+			withTempDir(func(target string) {
+				var buf bytes.Buffer
+				Expect(run(
+					withLogOutput(&buf),
+					withArgs(
+						"--url", repoURL,
+						"--target", target,
+					),
+				)).ToNot(Succeed())
+
+				Expect(attempts).To(Equal(1))
+				Expect(buf.String()).To(ContainSubstring("Attempt 1 of 4 to clone %s failed with an error that is not transient, not retrying", repoURL))
+			})
+		})
+	})
+
 	Context("cloning repositories with submodules", func() {
 		const exampleRepo = "https://github.com/shipwright-io/sample-submodule"
 
@@ -488,6 +1089,121 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("store commit details in result files", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			repoURL = localRepository("README.md")
+			gitIn(repoURL, "tag", "v1.0.0")
+			gitIn(repoURL, "tag", "--annotate", "release-1", "--message", "first release")
+		})
+
+		It("should store the committer, committer date, and commit subject", func() {
+			withTempFile("committer", func(committer string) {
+				withTempFile("committer-date", func(committerDate string) {
+					withTempFile("commit-subject", func(commitSubject string) {
+						withTempDir(func(target string) {
+							Expect(run(withArgs(
+								"--url", repoURL,
+								"--target", target,
+								"--result-file-committer", committer,
+								"--result-file-committer-date", committerDate,
+								"--result-file-commit-subject", commitSubject,
+							))).To(Succeed())
+
+							Expect(filecontent(committer)).To(Equal("shipwright"))
+							Expect(filecontent(committerDate)).To(Equal(gitIn(repoURL, "log", "-1", "--pretty=format:%ct")))
+							Expect(filecontent(commitSubject)).To(Equal("initial commit"))
+						})
+					})
+				})
+			})
+		})
+
+		It("should store the tags pointing at the commit and the output of git describe", func() {
+			withTempFile("tags", func(tags string) {
+				withTempFile("describe", func(describe string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", repoURL,
+							"--target", target,
+							"--revision", "v1.0.0",
+							"--result-file-tags", tags,
+							"--result-file-describe", describe,
+						))).To(Succeed())
+
+						Expect(strings.Split(filecontent(tags), ",")).To(ConsistOf("release-1", "v1.0.0"))
+						Expect(filecontent(describe)).To(BeElementOf("release-1", "v1.0.0"))
+					})
+				})
+			})
+		})
+
+		It("should store the output of git describe for a commit without tags", func() {
+			file(filepath.Join(strings.TrimPrefix(repoURL, "file://"), "CHANGELOG.md"), 0644, []byte("changes"))
+			gitIn(repoURL, "add", "CHANGELOG.md")
+			gitIn(repoURL, "commit", "-m", "add changelog")
+
+			withTempFile("tags", func(tags string) {
+				withTempFile("describe", func(describe string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", repoURL,
+							"--target", target,
+							"--result-file-tags", tags,
+							"--result-file-describe", describe,
+						))).To(Succeed())
+
+						Expect(filecontent(tags)).To(BeEmpty())
+						Expect(gitIn(repoURL, "rev-parse", "HEAD")).To(HavePrefix(filecontent(describe)))
+					})
+				})
+			})
+		})
+
+		It("should truncate the commit subject and omit tags that exceed the size of a result", func() {
+			file(filepath.Join(strings.TrimPrefix(repoURL, "file://"), "CHANGELOG.md"), 0644, []byte("changes"))
+			gitIn(repoURL, "add", "CHANGELOG.md")
+			gitIn(repoURL, "commit", "-m", strings.Repeat("ä", 1000))
+			for i := range 20 {
+				gitIn(repoURL, "tag", fmt.Sprintf("release-%02d-%s", i, strings.Repeat("x", 20)))
+			}
+
+			withTempFile("commit-subject", func(commitSubject string) {
+				withTempFile("tags", func(tags string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", repoURL,
+							"--target", target,
+							"--result-file-commit-subject", commitSubject,
+							"--result-file-tags", tags,
+						))).To(Succeed())
+
+						Expect(len(filecontent(commitSubject))).To(BeNumerically("<=", 256))
+						Expect(filecontent(commitSubject)).To(HaveSuffix("ä..."))
+
+						Expect(len(filecontent(tags))).To(BeNumerically("<=", 256))
+						Expect(strings.Split(filecontent(tags), ",")).To(HaveEach(MatchRegexp(`^release-\d{2}-x{20}$`)))
+					})
+				})
+			})
+		})
+
+		It("should store the remote URL", func() {
+			withTempFile("remote-url", func(remoteURL string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--result-file-remote-url", remoteURL,
+					))).To(Succeed())
+
+					Expect(filecontent(remoteURL)).To(Equal(repoURL))
+				})
+			})
+		})
+	})
+
 	Context("Some tests mutate or depend on git configurations. They must run sequentially to avoid race-conditions.", Ordered, func() {
 		Context("Test that require git configurations", func() {
 			Context("cloning repositories with Git Large File Storage", func() {
@@ -669,6 +1385,443 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("cloning repositories using a sparse checkout and a partial clone", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			repoURL = localRepository("README.md", "app/main.go", "docs/index.md", "tools/build/Makefile")
+		})
+
+		It("should only check out the root files and the given directories", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--filter", "blob:none",
+					"--sparse-checkout", "app",
+					"--sparse-checkout", "tools/build",
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "app", "main.go")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "tools", "build", "Makefile")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "docs")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should check out the given directories of a specific commit", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--revision", gitIn(repoURL, "rev-parse", "--short", "HEAD"),
+					"--sparse-checkout", "docs",
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "docs", "index.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "app")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("fetching refspecs", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			repoURL = localRepository("README.md")
+
+			// create a pull request ref that no branch points to
+			gitIn(repoURL, "checkout", "--quiet", "-b", "contributor")
+			file(filepath.Join(strings.TrimPrefix(repoURL, "file://"), "feature.txt"), 0644, []byte("feature"))
+			gitIn(repoURL, "add", "feature.txt")
+			gitIn(repoURL, "commit", "-m", "add feature")
+			gitIn(repoURL, "update-ref", "refs/pull/7/head", "HEAD")
+			gitIn(repoURL, "checkout", "--quiet", "main")
+			gitIn(repoURL, "branch", "-D", "contributor")
+		})
+
+		It("should fetch and check out a pull request ref and store the fetched ref", func() {
+			withTempFile("fetched-ref", func(fetchedRef string) {
+				withTempFile("branch-name", func(branchName string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", repoURL,
+							"--target", target,
+							"--refspec", "+refs/pull/7/head:refs/remotes/origin/pr/7",
+							"--result-file-fetched-ref", fetchedRef,
+							"--result-file-branch-name", branchName,
+						))).To(Succeed())
+
+						Expect(filepath.Join(target, "feature.txt")).To(BeAnExistingFile())
+						Expect(filecontent(fetchedRef)).To(Equal("refs/pull/7/head"))
+						Expect(filecontent(branchName)).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		It("should check out the requested commit of the fetched ref", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--refspec", "refs/pull/7/head",
+					"--revision", gitIn(repoURL, "rev-parse", "main"),
+					"--depth", "0",
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "feature.txt")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should fail for a ref that does not exist", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--refspec", "refs/pull/8/head",
+				))
+				Expect(err).To(HaveOccurred())
+
+				errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+				Expect(errorResult.Reason.String()).To(Equal(shpgit.RevisionNotFound.String()))
+			})
+		})
+	})
+
+	Context("using a CA bundle", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			repoURL = localRepository("README.md")
+		})
+
+		It("should ignore a CA bundle that does not exist", func() {
+			withTempDir(func(target string) {
+				GinkgoT().Setenv(util.CABundleFileEnvVar, filepath.Join(target, "ca-bundle.crt"))
+
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should fail for a CA bundle without certificates", func() {
+			withTempFile("ca-bundle", func(caBundle string) {
+				file(caBundle, 0644, []byte("not a certificate"))
+				GinkgoT().Setenv(util.CABundleFileEnvVar, caBundle)
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+					))
+					Expect(err).To(MatchError(ContainSubstring("does not contain any PEM encoded certificate")))
+				})
+			})
+		})
+	})
+
+	Context("using a cache", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			repoURL = localRepository("README.md")
+		})
+
+		mirrors := func(cacheDir string) []string {
+			matches, err := filepath.Glob(filepath.Join(cacheDir, "*.git"))
+			Expect(err).ToNot(HaveOccurred())
+			return matches
+		}
+
+		It("should populate the cache and clone a repository that does not depend on it", func() {
+			withTempDir(func(cacheDir string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--cache-dir", cacheDir,
+					))).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, ".git", "objects", "info", "alternates")).ToNot(BeAnExistingFile())
+					Expect(mirrors(cacheDir)).To(HaveLen(1))
+				})
+			})
+		})
+
+		It("should update the cache with new commits", func() {
+			withTempDir(func(cacheDir string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs("--url", repoURL, "--target", target, "--cache-dir", cacheDir))).To(Succeed())
+				})
+
+				file(filepath.Join(strings.TrimPrefix(repoURL, "file://"), "CHANGELOG.md"), 0644, []byte("changes"))
+				gitIn(repoURL, "add", "CHANGELOG.md")
+				gitIn(repoURL, "commit", "-m", "add changelog")
+
+				withTempDir(func(target string) {
+					Expect(run(withArgs("--url", repoURL, "--target", target, "--cache-dir", cacheDir))).To(Succeed())
+					Expect(filepath.Join(target, "CHANGELOG.md")).To(BeAnExistingFile())
+				})
+
+				Expect(mirrors(cacheDir)).To(HaveLen(1))
+				mirror := mirrors(cacheDir)[0]
+				Expect(gitIn("file://"+mirror, "rev-parse", "main")).To(Equal(gitIn(repoURL, "rev-parse", "main")))
+			})
+		})
+
+		It("should fetch a refspec using the cache", func() {
+			gitIn(repoURL, "update-ref", "refs/pull/7/head", "HEAD")
+
+			withTempDir(func(cacheDir string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--refspec", "refs/pull/7/head",
+						"--cache-dir", cacheDir,
+					))).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, ".git", "objects", "info", "alternates")).ToNot(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should evict the least recently used mirrors once the cache exceeds its maximum size", func() {
+			withTempDir(func(cacheDir string) {
+				unused := filepath.Join(cacheDir, "unused.git")
+				Expect(os.MkdirAll(filepath.Join(unused, "objects", "pack"), 0755)).To(Succeed())
+				file(filepath.Join(unused, "objects", "pack", "pack-unused.pack"), 0644, make([]byte, 1024))
+				Expect(os.Chtimes(unused, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))).To(Succeed())
+
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--cache-dir", cacheDir,
+						"--cache-max-size", "1",
+					))).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				})
+
+				Expect(unused).ToNot(BeADirectory())
+				Expect(mirrors(cacheDir)).To(HaveLen(1))
+			})
+		})
+
+		It("should not use the cache for a partial clone", func() {
+			withTempDir(func(cacheDir string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--filter", "blob:none",
+						"--sparse-checkout", "docs",
+						"--cache-dir", cacheDir,
+					))).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				})
+
+				Expect(mirrors(cacheDir)).To(BeEmpty())
+			})
+		})
+
+		It("should clone without the cache if it cannot be used", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--cache-dir", filepath.Join(target, "does-not-exist"),
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("cloning repositories with nested submodules", func() {
+		var repoURL string
+
+		BeforeEach(func() {
+			// submodules with a file URL are only cloned when the file protocol is allowed
+			for key, value := range map[string]string{"GIT_CONFIG_COUNT": "1", "GIT_CONFIG_KEY_0": "protocol.file.allow", "GIT_CONFIG_VALUE_0": "always"} {
+				previous, found := os.LookupEnv(key)
+				Expect(os.Setenv(key, value)).To(Succeed())
+				DeferCleanup(func() {
+					if found {
+						os.Setenv(key, previous)
+					} else {
+						os.Unsetenv(key)
+					}
+				})
+			}
+
+			nested := localRepository("nested.txt")
+			library := localRepository("library.txt")
+			addSubmodule(library, "nested", nested)
+			docs := localRepository("docs.txt")
+
+			repoURL = localRepository("README.md")
+			addSubmodule(repoURL, "library", library)
+			addSubmodule(repoURL, "docs", docs)
+		})
+
+		It("should initialize all submodules recursively by default", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs("--url", repoURL, "--target", target))).To(Succeed())
+
+				Expect(filepath.Join(target, "library", "nested", "nested.txt")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "docs", "docs.txt")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should not initialize submodules when disabled", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs("--url", repoURL, "--target", target, "--submodules=false"))).To(Succeed())
+
+				Expect(filepath.Join(target, "library", "library.txt")).ToNot(BeAnExistingFile())
+				Expect(filepath.Join(target, "docs", "docs.txt")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should only initialize the given submodules up to the given depth", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--submodule-path", "library",
+					"--submodule-depth", "1",
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "library", "library.txt")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "library", "nested", "nested.txt")).ToNot(BeAnExistingFile())
+				Expect(filepath.Join(target, "docs", "docs.txt")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should initialize nested submodules up to the given depth", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--submodule-depth", "2",
+				))).To(Succeed())
+
+				Expect(filepath.Join(target, "library", "nested", "nested.txt")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "docs", "docs.txt")).To(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("verifying signatures", func() {
+		var (
+			repoURL    string
+			signingKey string
+			verify     string
+		)
+
+		// newSigningKey creates an SSH key and returns the path to the private key and the public key
+		var newSigningKey = func(dir string, name string) (string, string) {
+			privateKey := filepath.Join(dir, name)
+			out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", name, "-f", privateKey).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return privateKey, strings.TrimSpace(filecontent(privateKey + ".pub"))
+		}
+
+		var signed = func(key string, args ...string) []string {
+			return append([]string{"-c", "gpg.format=ssh", "-c", "user.signingkey=" + key}, args...)
+		}
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("ssh-keygen"); err != nil {
+				Skip("Skipping signature verification tests as `ssh-keygen` binary is not in the PATH")
+			}
+
+			keys, err := os.MkdirTemp(os.TempDir(), "keys")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, keys)
+
+			var publicKey string
+			signingKey, publicKey = newSigningKey(keys, "trusted")
+
+			verify = filepath.Join(keys, "verify")
+			Expect(os.Mkdir(verify, 0700)).To(Succeed())
+			file(filepath.Join(verify, "ssh-allowed-signers"), 0400, []byte("shipwright@example.com "+publicKey+"\n"))
+
+			repoURL = localRepository("README.md")
+		})
+
+		It("should verify a signed commit and store the signer", func() {
+			gitIn(repoURL, signed(signingKey, "commit", "--allow-empty", "-S", "-m", "signed commit")...)
+
+			withTempFile("commit-signer", func(filename string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", repoURL,
+						"--target", target,
+						"--verify-secret-path", verify,
+						"--result-file-commit-signer", filename,
+					))).To(Succeed())
+
+					Expect(filecontent(filename)).To(Equal("shipwright@example.com"))
+				})
+			})
+		})
+
+		It("should verify a signed tag", func() {
+			gitIn(repoURL, signed(signingKey, "tag", "-s", "v1.0.0", "-m", "signed tag")...)
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--revision", "v1.0.0",
+					"--verify-secret-path", verify,
+				))).To(Succeed())
+			})
+		})
+
+		It("should fail for an unsigned commit", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--verify-secret-path", verify,
+				))
+				Expect(err).To(HaveOccurred())
+
+				errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+				Expect(errorResult.Reason.String()).To(Equal(shpgit.SignatureVerificationFailed.String()))
+			})
+		})
+
+		It("should fail for a commit that was signed by an untrusted key", func() {
+			untrustedKey, _ := newSigningKey(filepath.Dir(verify), "untrusted")
+			gitIn(repoURL, signed(untrustedKey, "commit", "--allow-empty", "-S", "-m", "signed commit")...)
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", repoURL,
+					"--target", target,
+					"--verify-secret-path", verify,
+				))
+				Expect(err).To(HaveOccurred())
+
+				errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+				Expect(errorResult.Reason.String()).To(Equal(shpgit.SignatureVerificationFailed.String()))
+			})
+		})
+	})
+
 	Context("Using show listing flag", func() {
 		const exampleRepo = "https://github.com/shipwright-io/sample-go"
 
@@ -0,0 +1,103 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	shpgit "github.com/shipwright-io/build/pkg/git"
+)
+
+// cloneWithRetries clones the repository and retries the clone with an exponential backoff while it fails with
+// a transient error, like a DNS failure or an error of the Git service. Other errors, like failed authentication
+// or a repository that does not exist, are returned immediately.
+func cloneWithRetries(ctx context.Context) error {
+	// a failed attempt may leave files behind, the next attempt starts with the target directory as it was
+	entries, err := os.ReadDir(flagValues.target)
+	targetExists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	existing := map[string]struct{}{}
+	for _, entry := range entries {
+		existing[entry.Name()] = struct{}{}
+	}
+
+	attempts := flagValues.retries + 1
+	delay := flagValues.retryDelay
+	for attempt := uint(1); ; attempt++ {
+		log.Printf("Attempt %d of %d to clone %s\n", attempt, attempts, displayURL)
+
+		err := clone(ctx)
+		if err == nil {
+			log.Printf("Cloned %s in attempt %d of %d\n", displayURL, attempt, attempts)
+			return nil
+		}
+
+		if !isTransientError(err) {
+			log.Printf("Attempt %d of %d to clone %s failed with an error that is not transient, not retrying\n", attempt, attempts, displayURL)
+			return err
+		}
+
+		if attempt == attempts {
+			log.Printf("Attempt %d of %d to clone %s failed with a transient error, giving up\n", attempt, attempts, displayURL)
+			return err
+		}
+
+		log.Printf("Attempt %d of %d to clone %s failed with a transient error, retrying in %s: %s\n", attempt, attempts, displayURL, delay, failureDetail(err))
+
+		if err := resetTarget(targetExists, existing); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+func isTransientError(err error) bool {
+	var exitError *ExitError
+	if !errors.As(err, &exitError) || exitError.Reason != shpgit.Unknown {
+		return false
+	}
+
+	return shpgit.IsTransientError(exitError.Message)
+}
+
+// resetTarget removes what a failed clone created in the target directory, entries that existed before are kept
+func resetTarget(targetExists bool, existing map[string]struct{}) error {
+	if !targetExists {
+		return os.RemoveAll(flagValues.target)
+	}
+
+	entries, err := os.ReadDir(flagValues.target)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if _, ok := existing[entry.Name()]; ok {
+			continue
+		}
+
+		if err := os.RemoveAll(filepath.Join(flagValues.target, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	shpgit "github.com/shipwright-io/build/pkg/git"
+)
+
+// The keys of the verify secret, they match the ones documented in the Build API
+const (
+	verifyGPGPublicKeys    = "gpg-public-keys"
+	verifySSHAllowedSigner = "ssh-allowed-signers"
+	verifyGitsignIdentity  = "gitsign-identities"
+)
+
+var (
+	gpgGoodSignatureRegEx = regexp.MustCompile(`Good signature from "([^"]+)"`)
+	sshGoodSignatureRegEx = regexp.MustCompile(`Good "git" signature for (\S+) with`)
+)
+
+// verifySignature verifies the signature of the checked out commit, or of the tag in case the revision
+// is an annotated tag, using the trusted keys and identities of the verify secret. It returns the
+// identity of the signer.
+func verifySignature(ctx context.Context) (string, error) {
+	objectType, object, verifyCommand := "commit", "HEAD", "verify-commit"
+	if flagValues.revision != "" {
+		tagRef := "refs/tags/" + flagValues.revision
+		if out, err := git(ctx, "-C", flagValues.target, "cat-file", "-t", tagRef); err == nil && out == "tag" {
+			objectType, object, verifyCommand = "tag", tagRef, "verify-tag"
+		}
+	}
+
+	raw, err := git(ctx, "-C", flagValues.target, "cat-file", objectType, object)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.Contains(raw, "-----BEGIN PGP SIGNATURE-----"):
+		return verifyGPGSignature(ctx, objectType, object, verifyCommand)
+
+	case strings.Contains(raw, "-----BEGIN SSH SIGNATURE-----"):
+		return verifySSHSignature(ctx, objectType, object, verifyCommand)
+
+	case strings.Contains(raw, "-----BEGIN SIGNED MESSAGE-----"):
+		return verifyGitsignSignature(ctx, objectType, object)
+
+	default:
+		return "", signatureVerificationError(objectType, object, "it is not signed")
+	}
+}
+
+func verifyGPGSignature(ctx context.Context, objectType string, object string, verifyCommand string) (string, error) {
+	publicKeys := filepath.Join(flagValues.verifySecretPath, verifyGPGPublicKeys)
+	if !hasFile(publicKeys) {
+		return "", signatureVerificationError(objectType, object, fmt.Sprintf("it has a GPG signature, but the secret has no %s", verifyGPGPublicKeys))
+	}
+
+	// import the trusted keys into a keyring that only contains these
+	gnupgHome, err := os.MkdirTemp(os.TempDir(), "gnupg")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(gnupgHome)
+
+	if out, err := exec.CommandContext(ctx, "gpg", "--homedir", gnupgHome, "--batch", "--quiet", "--import", publicKeys).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to import the GPG public keys: %s", strings.TrimSpace(string(out)))
+	}
+
+	previous, found := os.LookupEnv("GNUPGHOME")
+	os.Setenv("GNUPGHOME", gnupgHome)
+	defer func() {
+		if found {
+			os.Setenv("GNUPGHOME", previous)
+		} else {
+			os.Unsetenv("GNUPGHOME")
+		}
+	}()
+
+	out, err := git(ctx, "-C", flagValues.target, "-c", "gpg.format=openpgp", verifyCommand, object)
+	if err != nil {
+		return "", signatureVerificationError(objectType, object, failureDetail(err))
+	}
+
+	return signerOf(gpgGoodSignatureRegEx, out), nil
+}
+
+func verifySSHSignature(ctx context.Context, objectType string, object string, verifyCommand string) (string, error) {
+	allowedSigners := filepath.Join(flagValues.verifySecretPath, verifySSHAllowedSigner)
+	if !hasFile(allowedSigners) {
+		return "", signatureVerificationError(objectType, object, fmt.Sprintf("it has an SSH signature, but the secret has no %s", verifySSHAllowedSigner))
+	}
+
+	out, err := git(ctx, "-C", flagValues.target, "-c", fmt.Sprintf("gpg.ssh.allowedSignersFile=%s", allowedSigners), verifyCommand, object)
+	if err != nil {
+		return "", signatureVerificationError(objectType, object, failureDetail(err))
+	}
+
+	return signerOf(sshGoodSignatureRegEx, out), nil
+}
+
+func verifyGitsignSignature(ctx context.Context, objectType string, object string) (string, error) {
+	identities := filepath.Join(flagValues.verifySecretPath, verifyGitsignIdentity)
+	if !hasFile(identities) {
+		return "", signatureVerificationError(objectType, object, fmt.Sprintf("it has a gitsign signature, but the secret has no %s", verifyGitsignIdentity))
+	}
+
+	gitsign, err := exec.LookPath("gitsign")
+	if err != nil {
+		return "", signatureVerificationError(objectType, object, "it has a gitsign signature, but gitsign is not installed")
+	}
+
+	file, err := os.Open(identities)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	verifyCommand := "verify"
+	if objectType == "tag" {
+		verifyCommand = "verify-tag"
+	}
+
+	// the signature is trusted if the certificate was issued to one of the identities
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		identity, issuer, found := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		if !found || strings.HasPrefix(identity, "#") {
+			continue
+		}
+
+		cmd := exec.CommandContext(ctx, gitsign, verifyCommand,
+			fmt.Sprintf("--certificate-identity=%s", identity),
+			fmt.Sprintf("--certificate-oidc-issuer=%s", strings.TrimSpace(issuer)),
+			object,
+		)
+		cmd.Dir = flagValues.target
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			log.Printf("Signature was not made by %s: %s\n", identity, lastLine(string(out)))
+			continue
+		}
+
+		return identity, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", signatureVerificationError(objectType, object, "it was not made by one of the trusted identities")
+}
+
+func signatureVerificationError(objectType string, object string, detail string) error {
+	return &ExitError{
+		Code:    130,
+		Message: fmt.Sprintf("fatal: signature verification failed for %s %s, %s", objectType, strings.TrimPrefix(object, "refs/tags/"), detail),
+		Reason:  shpgit.SignatureVerificationFailed,
+	}
+}
+
+// signerOf returns the signer from the output of a verify command, or an empty string if there is none
+func signerOf(regEx *regexp.Regexp, out string) string {
+	if match := regEx.FindStringSubmatch(out); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// failureDetail returns the last line of the output of a failed command, which usually explains the failure
+func failureDetail(err error) string {
+	var exitError *ExitError
+	if errors.As(err, &exitError) {
+		return lastLine(exitError.Message)
+	}
+
+	return err.Error()
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -18,6 +18,14 @@ const (
 
 	// LabelBuildRunGeneration is a label key for BuildRuns to define the generation
 	LabelBuildRunGeneration = BuildRunDomain + "/generation"
+
+	// AnnotationBuildRunSourceGitRevision is an annotation key for BuildRuns that holds the Git revision
+	// override of a v1beta1 BuildRun, which has no counterpart in v1alpha1
+	AnnotationBuildRunSourceGitRevision = BuildRunDomain + "/source.git.revision"
+
+	// AnnotationBuildRunSourceGitSigner is an annotation key for BuildRuns that holds the signer of the
+	// Git source of a v1beta1 BuildRun status, which has no counterpart in v1alpha1
+	AnnotationBuildRunSourceGitSigner = BuildRunDomain + "/source.git.signer"
 )
 
 // BuildRunSpec defines the desired state of BuildRun
@@ -358,13 +358,19 @@ func (p TriggerWhen) convertToAlpha(dest *v1alpha1.TriggerWhen) {
 	dest.Name = p.Name
 	dest.Type = v1alpha1.TriggerType(p.Type)
 
-	dest.GitHub = &v1alpha1.WhenGitHub{}
-	for _, e := range p.GitHub.Events {
-		dest.GitHub.Events = append(dest.GitHub.Events, v1alpha1.GitHubEventName(e))
+	if p.GitHub != nil {
+		dest.GitHub = &v1alpha1.WhenGitHub{}
+		for _, e := range p.GitHub.Events {
+			dest.GitHub.Events = append(dest.GitHub.Events, v1alpha1.GitHubEventName(e))
+		}
+		dest.GitHub.Branches = p.GetBranches(GitHubWebHookTrigger)
+		// Note: AllowForks is not supported in v1alpha1 and therefore
+		// dropped, pull-requests from forks then no longer trigger the Build.
 	}
-	dest.GitHub.Branches = p.GetBranches(GitHubWebHookTrigger)
 
-	dest.Image = (*v1alpha1.WhenImage)(p.Image)
+	if p.Image != nil {
+		dest.Image = &v1alpha1.WhenImage{Names: p.Image.Names}
+	}
 	dest.ObjectRef = (*v1alpha1.WhenObjectRef)(p.ObjectRef)
 
 }
@@ -403,13 +409,17 @@ func convertToBetaTriggers(orig *v1alpha1.TriggerWhen) TriggerWhen {
 		Type: TriggerType(orig.Type),
 	}
 
-	dest.GitHub = &WhenGitHub{}
-	for _, e := range orig.GitHub.Events {
-		dest.GitHub.Events = append(dest.GitHub.Events, GitHubEventName(e))
-	}
+	if orig.GitHub != nil {
+		dest.GitHub = &WhenGitHub{}
+		for _, e := range orig.GitHub.Events {
+			dest.GitHub.Events = append(dest.GitHub.Events, GitHubEventName(e))
+		}
 
-	dest.GitHub.Branches = orig.GetBranches(v1alpha1.GitHubWebHookTrigger)
-	dest.Image = (*WhenImage)(orig.Image)
+		dest.GitHub.Branches = orig.GetBranches(v1alpha1.GitHubWebHookTrigger)
+	}
+	if orig.Image != nil {
+		dest.Image = &WhenImage{Names: orig.Image.Names}
+	}
 	dest.ObjectRef = (*WhenObjectRef)(orig.ObjectRef)
 
 	return dest
@@ -28,6 +28,8 @@ const (
 	SpecSourceSecretRefNotFound BuildReason = "SpecSourceSecretRefNotFound"
 	// SpecOutputSecretRefNotFound indicates the referenced secret in output is missing
 	SpecOutputSecretRefNotFound BuildReason = "SpecOutputSecretRefNotFound"
+	// SpecTriggerSecretRefNotFound indicates the referenced secret in trigger is missing
+	SpecTriggerSecretRefNotFound BuildReason = "SpecTriggerSecretRefNotFound"
 	// SpecBuilderSecretRefNotFound indicates the referenced secret in builder is missing
 	SpecBuilderSecretRefNotFound BuildReason = "SpecBuilderSecretRefNotFound"
 	// MultipleSecretRefNotFound indicates that multiple secrets are missing
@@ -72,6 +74,18 @@ const (
 	TriggerInvalidImage BuildReason = "TriggerInvalidImage"
 	// TriggerInvalidPipeline indicates the trigger type Pipeline is invalid
 	TriggerInvalidPipeline BuildReason = "TriggerInvalidPipeline"
+	// TriggerInvalidGitLabWebHook indicates the trigger type GitLab is invalid
+	TriggerInvalidGitLabWebHook BuildReason = "TriggerInvalidGitLabWebHook"
+	// TriggerInvalidGiteaWebHook indicates the trigger type Gitea is invalid
+	TriggerInvalidGiteaWebHook BuildReason = "TriggerInvalidGiteaWebHook"
+	// TriggerInvalidBitbucketWebHook indicates the trigger type Bitbucket is invalid
+	TriggerInvalidBitbucketWebHook BuildReason = "TriggerInvalidBitbucketWebHook"
+	// TriggerInvalidSchedule indicates the trigger type Schedule is invalid
+	TriggerInvalidSchedule BuildReason = "TriggerInvalidSchedule"
+	// TriggerSecretNotDefined indicates the Build has webhook trigger conditions but no trigger secret
+	TriggerSecretNotDefined BuildReason = "TriggerSecretNotDefined"
+	// TriggerInvalidPathPattern indicates a path pattern of the trigger is malformed
+	TriggerInvalidPathPattern BuildReason = "TriggerInvalidPathPattern"
 	// OutputTimestampNotSupported indicates that an unsupported output timestamp setting was used
 	OutputTimestampNotSupported BuildReason = "OutputTimestampNotSupported"
 	// OutputTimestampNotValid indicates that the output timestamp value is not valid
@@ -82,6 +96,12 @@ const (
 	TolerationNotValid BuildReason = "TolerationNotValid"
 	// SchedulerNameNotValid indicates that the Scheduler name is not valid
 	SchedulerNameNotValid BuildReason = "SchedulerNameNotValid"
+	// SourcesInvalid indicates that the named sources of the Build are not valid
+	SourcesInvalid BuildReason = "SourcesInvalid"
+	// BundleLimitsNotValid indicates that the limits of a source bundle exceed the ones of the cluster
+	BundleLimitsNotValid BuildReason = "BundleLimitsNotValid"
+	// NetworkConfigMapNotFound indicates that the ConfigMap with the network settings of the Build does not exist
+	NetworkConfigMapNotFound BuildReason = "NetworkConfigMapNotFound"
 	// AllValidationsSucceeded indicates a Build was successfully validated
 	AllValidationsSucceeded = "all validations succeeded"
 )
@@ -140,6 +160,15 @@ type BuildSpec struct {
 	// +optional
 	Source *Source `json:"source"`
 
+	// Sources are additional named sources, each fetched into its own sub-directory of the
+	// source workspace, for example a repository with shared configuration next to the
+	// application source code.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Sources []NamedSource `json:"sources,omitempty"`
+
 	// Trigger defines the scenarios where a new build should be triggered.
 	//
 	// +optional
@@ -196,8 +225,38 @@ type BuildSpec struct {
 	// SchedulerName specifies the scheduler to be used to dispatch the Pod
 	// +optional
 	SchedulerName *string `json:"schedulerName,omitempty"`
+
+	// Network references a ConfigMap with the CA certificates and proxy settings that the steps
+	// Shipwright adds to fetch the sources and to process the image use, instead of the settings
+	// of the cluster.
+	//
+	// +optional
+	Network *BuildNetwork `json:"network,omitempty"`
+}
+
+// BuildNetwork configures the network access of the steps that Shipwright adds to the build
+type BuildNetwork struct {
+	// ConfigMap is the name of a ConfigMap in the namespace of the Build. The CA certificates of its
+	// ca-bundle.crt key are trusted in addition to the system roots, its httpProxy, httpsProxy,
+	// and noProxy keys configure the proxies.
+	ConfigMap string `json:"configMap"`
 }
 
+// The keys of the ConfigMap referenced by a BuildNetwork.
+const (
+	// BuildNetworkCABundleKey holds the PEM encoded CA certificates that are trusted.
+	BuildNetworkCABundleKey = "ca-bundle.crt"
+
+	// BuildNetworkHTTPProxyKey holds the proxy for HTTP connections.
+	BuildNetworkHTTPProxyKey = "httpProxy"
+
+	// BuildNetworkHTTPSProxyKey holds the proxy for HTTPS connections.
+	BuildNetworkHTTPSProxyKey = "httpsProxy"
+
+	// BuildNetworkNoProxyKey holds the comma-separated hosts and domains that are accessed without proxy.
+	BuildNetworkNoProxyKey = "noProxy"
+)
+
 // BuildVolume is a volume that will be mounted in build pod during build step
 type BuildVolume struct {
 	// Name of the Build Volume
@@ -318,6 +377,14 @@ type BuildStatus struct {
 	// The message of the registered Build, either an error or succeed message
 	// +optional
 	Message *string `json:"message,omitempty"`
+
+	// ImageTriggers holds the last seen digests of the images watched by Image triggers
+	// +optional
+	ImageTriggers []ImageTriggerStatus `json:"imageTriggers,omitempty"`
+
+	// ScheduleTriggers holds the last schedule times of the Schedule triggers
+	// +optional
+	ScheduleTriggers []ScheduleTriggerStatus `json:"scheduleTriggers,omitempty"`
 }
 
 // +genclient
@@ -403,3 +470,59 @@ func (b Build) GetSourceCredentials() *string {
 	}
 	return nil
 }
+
+// GetSourceVerificationSecrets returns the names of the secrets with the trusted keys that are
+// used to verify the signatures of the Git sources
+func (b Build) GetSourceVerificationSecrets() []string {
+	var secrets []string
+	if b.Spec.Source != nil && b.Spec.Source.Type == GitType && b.Spec.Source.Git != nil && b.Spec.Source.Git.Verify != nil {
+		secrets = append(secrets, b.Spec.Source.Git.Verify.Secret)
+	}
+
+	for _, source := range b.Spec.Sources {
+		if source.Type == GitType && source.Git != nil && source.Git.Verify != nil {
+			secrets = append(secrets, source.Git.Verify.Secret)
+		}
+	}
+
+	return secrets
+}
+
+// GetSourceCloneSecrets returns the names of the secrets with the credentials for other URLs
+// than the ones of the Git sources, like the URLs of submodules
+func (b Build) GetSourceCloneSecrets() []string {
+	var secrets []string
+	if b.Spec.Source != nil && b.Spec.Source.Type == GitType && b.Spec.Source.Git != nil {
+		for _, cloneSecret := range b.Spec.Source.Git.CloneSecrets {
+			secrets = append(secrets, cloneSecret.Secret)
+		}
+	}
+
+	for _, source := range b.Spec.Sources {
+		if source.Type == GitType && source.Git != nil {
+			for _, cloneSecret := range source.Git.CloneSecrets {
+				secrets = append(secrets, cloneSecret.Secret)
+			}
+		}
+	}
+
+	return secrets
+}
+
+// GetNamedSourcesCredentials returns the secret names of the named Build Sources
+func (b Build) GetNamedSourcesCredentials() []string {
+	var secrets []string
+	for _, source := range b.Spec.Sources {
+		switch source.Type {
+		case OCIArtifactType:
+			if source.OCIArtifact != nil && source.OCIArtifact.PullSecret != nil {
+				secrets = append(secrets, *source.OCIArtifact.PullSecret)
+			}
+		case GitType:
+			if source.Git != nil && source.Git.CloneSecret != nil {
+				secrets = append(secrets, *source.Git.CloneSecret)
+			}
+		}
+	}
+	return secrets
+}
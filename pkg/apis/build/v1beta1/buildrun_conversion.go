@@ -11,6 +11,7 @@ import (
 	"github.com/shipwright-io/build/pkg/ctxlog"
 	"github.com/shipwright-io/build/pkg/webhook"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
@@ -112,10 +113,15 @@ func (src *BuildRun) ConvertTo(ctx context.Context, obj *unstructured.Unstructur
 	var sourceStatus []v1alpha1.SourceResult
 	if src.Status.Source != nil && src.Status.Source.Git != nil {
 		// Note: v1alpha contains a Name field under the SourceResult
-		// object, which we dont set here.
+		// object, which we dont set here. The signer is not supported in
+		// v1alpha1 and therefore kept in an annotation.
 		sourceStatus = append(sourceStatus, v1alpha1.SourceResult{
-			Name:      "default",
-			Git:       (*v1alpha1.GitSourceResult)(src.Status.Source.Git),
+			Name: "default",
+			Git: &v1alpha1.GitSourceResult{
+				CommitSha:    src.Status.Source.Git.CommitSha,
+				CommitAuthor: src.Status.Source.Git.CommitAuthor,
+				BranchName:   src.Status.Source.Git.BranchName,
+			},
 			Timestamp: src.Status.Source.Timestamp,
 		})
 	}
@@ -181,6 +187,14 @@ func (src *BuildRun) ConvertTo(ctx context.Context, obj *unstructured.Unstructur
 		alphaBuildRun.Status.BuildSpec = aux
 	}
 
+	// convert annotation-controlled features
+	if src.Spec.Source != nil && src.Spec.Source.Type == GitType && src.Spec.Source.Git != nil && src.Spec.Source.Git.Revision != nil {
+		setAlphaAnnotation(&alphaBuildRun.ObjectMeta, v1alpha1.AnnotationBuildRunSourceGitRevision, *src.Spec.Source.Git.Revision)
+	}
+	if src.Status.Source != nil && src.Status.Source.Git != nil && src.Status.Source.Git.Signer != "" {
+		setAlphaAnnotation(&alphaBuildRun.ObjectMeta, v1alpha1.AnnotationBuildRunSourceGitSigner, src.Status.Source.Git.Signer)
+	}
+
 	mapito, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&alphaBuildRun)
 	if err != nil {
 		ctxlog.Error(ctx, err, "failed structuring the newObject")
@@ -213,10 +227,17 @@ func (src *BuildRun) ConvertFrom(ctx context.Context, obj *unstructured.Unstruct
 	var sourceStatus *SourceResult
 	for _, s := range alphaBuildRun.Status.Sources {
 		sourceStatus = &SourceResult{
-			Git:         (*GitSourceResult)(s.Git),
 			OciArtifact: (*OciArtifactSourceResult)(s.Bundle),
 			Timestamp:   s.Timestamp,
 		}
+
+		if s.Git != nil {
+			sourceStatus.Git = &GitSourceResult{
+				CommitSha:    s.Git.CommitSha,
+				CommitAuthor: s.Git.CommitAuthor,
+				BranchName:   s.Git.BranchName,
+			}
+		}
 	}
 
 	conditions := []Condition{}
@@ -264,6 +285,24 @@ func (src *BuildRun) ConvertFrom(ctx context.Context, obj *unstructured.Unstruct
 		src.Status.BuildSpec = &buildBeta.Spec
 	}
 
+	// convert annotation-controlled features
+	if value, set := alphaBuildRun.Annotations[v1alpha1.AnnotationBuildRunSourceGitRevision]; set {
+		// the override only applies to a Git source, a local source that was added in v1alpha1 replaces it
+		if src.Spec.Source == nil {
+			src.Spec.Source = &BuildRunSource{Type: GitType}
+		}
+		if src.Spec.Source.Type == GitType {
+			src.Spec.Source.Git = &BuildRunGit{Revision: ptr.To(value)}
+		}
+		delete(src.ObjectMeta.Annotations, v1alpha1.AnnotationBuildRunSourceGitRevision)
+	}
+	if value, set := alphaBuildRun.Annotations[v1alpha1.AnnotationBuildRunSourceGitSigner]; set {
+		if src.Status.Source != nil && src.Status.Source.Git != nil {
+			src.Status.Source.Git.Signer = value
+		}
+		delete(src.ObjectMeta.Annotations, v1alpha1.AnnotationBuildRunSourceGitSigner)
+	}
+
 	return nil
 }
 
@@ -339,3 +378,14 @@ func (dest *BuildRunSpec) ConvertFrom(orig *v1alpha1.BuildRunSpec) error {
 	}
 	return nil
 }
+
+// setAlphaAnnotation sets an annotation on the metadata of a converted object, the annotations
+// are copied into a new map as otherwise the addition is not kept
+func setAlphaAnnotation(meta *metav1.ObjectMeta, key string, value string) {
+	annotations := map[string]string{}
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[key] = value
+	meta.Annotations = annotations
+}
@@ -18,6 +18,30 @@ const (
 
 	// LabelBuildRunGeneration is a label key for BuildRuns to define the generation
 	LabelBuildRunGeneration = BuildRunDomain + "/generation"
+
+	// AnnotationTriggerName is an annotation key for BuildRuns created by a trigger, it holds
+	// the name of the trigger condition that matched the event
+	AnnotationTriggerName = BuildRunDomain + "/trigger.name"
+
+	// AnnotationTriggerType is an annotation key for BuildRuns created by a trigger, it holds
+	// the type of the trigger condition that matched the event
+	AnnotationTriggerType = BuildRunDomain + "/trigger.type"
+
+	// AnnotationTriggerObjectKind is an annotation key for BuildRuns created by a trigger, it holds
+	// the kind of the object that caused the BuildRun, for example PipelineRun
+	AnnotationTriggerObjectKind = BuildRunDomain + "/trigger.object.kind"
+
+	// AnnotationTriggerObjectName is an annotation key for BuildRuns created by a trigger, it holds
+	// the name of the object that caused the BuildRun
+	AnnotationTriggerObjectName = BuildRunDomain + "/trigger.object.name"
+
+	// LabelTriggerObjectUID is a label key for BuildRuns created by a trigger, it holds the UID
+	// of the object that caused the BuildRun
+	LabelTriggerObjectUID = BuildRunDomain + "/trigger.object.uid"
+
+	// AnnotationCommitStatus is an annotation key for BuildRuns of Builds that report their status to
+	// the Git service, it holds the commit status that was last reported
+	AnnotationCommitStatus = BuildRunDomain + "/commit-status"
 )
 
 // VulnerabilitySeverity is an enum for the possible values for severity of a vulnerability
@@ -59,9 +83,9 @@ type BuildRunSpec struct {
 	//
 	Build ReferencedBuild `json:"build"`
 
-	// Source overrides where the source code is obtained for the BuildRun. This can only be used
+	// Source overrides where the source code is obtained for the BuildRun. This can be used
 	// to obtain source code from a remote machine's local directory, instead of the value defined
-	// in the build.
+	// in the build, or to override the revision of the Git source defined in the build.
 	//
 	// +optional
 	Source *BuildRunSource `json:"source,omitempty"`
@@ -167,6 +191,12 @@ type SourceResult struct {
 	// +optional
 	OciArtifact *OciArtifactSourceResult `json:"ociArtifact,omitempty"`
 
+	// HTTP holds the results emitted from
+	// the source step of type HTTP
+	//
+	// +optional
+	HTTP *HTTPSourceResult `json:"http,omitempty"`
+
 	// Timestamp holds the timestamp of the source, which
 	// depends on the actual source type and could range from
 	// being the commit timestamp or the fileystem timestamp
@@ -176,12 +206,26 @@ type SourceResult struct {
 	Timestamp *metav1.Time `json:"timestamp,omitempty"`
 }
 
+// NamedSourceResult holds the results emitted from the source step of a named source
+type NamedSourceResult struct {
+	// Name is the name of the source
+	Name string `json:"name"`
+
+	SourceResult `json:",inline"`
+}
+
 // OciArtifactSourceResult holds the results emitted from the bundle source
 type OciArtifactSourceResult struct {
 	// Digest hold the image digest result
 	Digest string `json:"digest,omitempty"`
 }
 
+// HTTPSourceResult holds the results emitted from the HTTP source
+type HTTPSourceResult struct {
+	// Digest holds the sha256 digest of the downloaded archive
+	Digest string `json:"digest,omitempty"`
+}
+
 // GitSourceResult holds the results emitted from the git source
 type GitSourceResult struct {
 	// CommitSha holds the commit sha of git source
@@ -190,11 +234,69 @@ type GitSourceResult struct {
 	// CommitAuthor holds the commit author of a git source
 	CommitAuthor string `json:"commitAuthor,omitempty"`
 
+	// Committer holds the committer of the commit
+	//
+	// +optional
+	Committer string `json:"committer,omitempty"`
+
+	// CommitterDate holds the date when the commit was committed
+	//
+	// +optional
+	CommitterDate *metav1.Time `json:"committerDate,omitempty"`
+
+	// CommitSubject holds the subject, which is the first line, of the commit message
+	//
+	// +optional
+	CommitSubject string `json:"commitSubject,omitempty"`
+
 	// BranchName holds the default branch name of the git source
 	// this will be set only when revision is not specified in Build object
 	//
 	// +optional
 	BranchName string `json:"branchName,omitempty"`
+
+	// FetchedRef holds the reference that was fetched, this will be set only when a refspec is
+	// specified in Build object
+	//
+	// +optional
+	FetchedRef string `json:"fetchedRef,omitempty"`
+
+	// Signer holds the identity that signed the commit or tag, it is only set when the
+	// signature was verified
+	//
+	// +optional
+	Signer string `json:"signer,omitempty"`
+
+	// Tags holds the tags of the repository that point at the commit
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Describe holds the output of git describe for the commit, which is the abbreviated
+	// commit sha if the clone does not contain any tag that the commit is based on
+	//
+	// +optional
+	Describe string `json:"describe,omitempty"`
+
+	// RemoteURL holds the URL of the repository without credentials
+	//
+	// +optional
+	RemoteURL string `json:"remoteURL,omitempty"`
+}
+
+// ResolvedGitRevision holds the commit that the revision of the Git source pointed to when the
+// BuildRun was started
+type ResolvedGitRevision struct {
+	// Revision holds the requested revision, it is empty if the default branch was requested
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Ref holds the full name of the reference the revision was resolved from, for example refs/heads/main
+	Ref string `json:"ref"`
+
+	// CommitSha holds the commit sha the reference pointed to, this commit is cloned by the source step
+	CommitSha string `json:"commitSha"`
 }
 
 // Vulnerability defines a vulnerability by its ID and severity
@@ -228,6 +330,19 @@ type BuildRunStatus struct {
 	// +optional
 	Source *SourceResult `json:"source,omitempty"`
 
+	// Sources holds the results emitted from the source steps of the named sources
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Sources []NamedSourceResult `json:"sources,omitempty"`
+
+	// ResolvedGitRevision holds the commit the revision of the Git source was resolved to before
+	// the TaskRun was created, it is only set when the controller resolves revisions
+	//
+	// +optional
+	ResolvedGitRevision *ResolvedGitRevision `json:"resolvedGitRevision,omitempty"`
+
 	// Output holds the results emitted from step definition of an output
 	//
 	// +optional
@@ -4,7 +4,10 @@
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // PruneOption defines the supported options for image pruning
 type PruneOption string
@@ -23,6 +26,9 @@ const GitType BuildSourceType = "Git"
 // OCIArtifactType represents a build whose source code is in a "scratch" container image, also known as an OCI artifact.
 const OCIArtifactType BuildSourceType = "OCI"
 
+// HTTPType represents a build whose source code is in an archive that is downloaded from a HTTP(S) URL.
+const HTTPType BuildSourceType = "HTTP"
+
 const (
 	// Do not delete image after it was pulled
 	PruneNever PruneOption = "Never"
@@ -57,12 +63,29 @@ type Git struct {
 	// +optional
 	Revision *string `json:"revision,omitempty"`
 
+	// Refspec describes a reference that is fetched and checked out instead of a branch. Use it
+	// for references that a clone does not fetch, like GitHub pull request refs (refs/pull/<n>/head
+	// or refs/pull/<n>/merge), GitLab merge request refs (refs/merge-requests/<n>/head), or Gerrit
+	// change refs (refs/changes/<nn>/<change>/<patchset>). If a revision is defined as well, it must
+	// be a commit SHA of the fetched history, which is checked out then.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\+?refs/[^:\s]+(:refs/[^:\s]+)?$`
+	Refspec *string `json:"refspec,omitempty"`
+
 	// CloneSecret references a Secret that contains credentials to access
 	// the repository.
 	//
 	// +optional
 	CloneSecret *string `json:"cloneSecret,omitempty"`
 
+	// CloneSecrets maps URL prefixes to Secrets with the credentials for the repositories whose URLs
+	// start with them, for example for submodules that are hosted on other Git services. They take
+	// precedence over the CloneSecret for these URLs. The Secrets hold credentials for HTTPS.
+	//
+	// +optional
+	CloneSecrets []GitCloneSecret `json:"cloneSecrets,omitempty"`
+
 	// Depth specifies the depth of the shallow clone.
 	// If not specified the default is set to 1.
 	// Values greater than 1 will create a clone with the specified depth.
@@ -70,6 +93,188 @@ type Git struct {
 	//
 	// +optional
 	Depth *int `json:"depth,omitempty"`
+
+	// SparseCheckout lists directories of the repository that are checked out using a cone mode
+	// sparse checkout. Files in the root directory of the repository are always checked out. The
+	// context directory is added automatically. If not specified, the full repository is checked out.
+	//
+	// +optional
+	SparseCheckout []string `json:"sparseCheckout,omitempty"`
+
+	// Filter creates a partial clone that only fetches the objects matching the filter, objects
+	// needed for the checkout are fetched on demand. Supported values are `blob:none`,
+	// `blob:limit=<n>[kmg]`, and `tree:<depth>`.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(blob:none|blob:limit=[0-9]+[kmg]?|tree:[0-9]+)$`
+	Filter *string `json:"filter,omitempty"`
+
+	// Submodules configures which submodules of the repository are initialized. If not specified,
+	// all submodules are initialized recursively.
+	//
+	// +optional
+	Submodules *GitSubmodules `json:"submodules,omitempty"`
+
+	// LFS configures which Git Large File Storage (LFS) files are fetched. If not specified, all
+	// LFS files are fetched.
+	//
+	// +optional
+	LFS *GitLFS `json:"lfs,omitempty"`
+
+	// Verify configures the verification of the signature of the checked out commit, or of the
+	// tag if the revision is an annotated tag. The source step fails if the signature is missing
+	// or was not made by one of the trusted keys.
+	//
+	// +optional
+	Verify *GitVerify `json:"verify,omitempty"`
+
+	// StatusReport configures reporting the status of BuildRuns as commit status to the Git service
+	// hosting the repository.
+	//
+	// +optional
+	StatusReport *GitStatusReport `json:"statusReport,omitempty"`
+
+	// Cache configures the persistent cache of the Git objects of the repository, which is shared
+	// across BuildRuns so that a clone only fetches the objects that are new. If not specified, the
+	// cache is used if the cluster administrator configured one.
+	//
+	// +optional
+	Cache *GitCache `json:"cache,omitempty"`
+}
+
+// GitCloneSecret references a Secret with the credentials for the repositories whose URLs start with a prefix.
+type GitCloneSecret struct {
+	// URLPrefix is the start of the URLs that the credentials are used for, for example
+	// https://gitlab.example.com/ or https://github.com/organization/.
+	//
+	// +kubebuilder:validation:Pattern=`^https://[^/]+(/.*)?$`
+	URLPrefix string `json:"urlPrefix"`
+
+	// Secret references a Secret in the namespace of the Build with a username and password, an
+	// access token, or a GitHub App.
+	Secret string `json:"secret"`
+}
+
+// GitCache configures the persistent cache of the Git objects of a repository.
+type GitCache struct {
+	// Enabled controls whether the cache is used. If not specified, the cache is used.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// PersistentVolumeClaim is the name of a PersistentVolumeClaim in the namespace of the Build
+	// that holds the cache, instead of the one configured for the cluster. BuildRuns running in
+	// parallel on different nodes need an access mode like ReadWriteMany to mount it.
+	//
+	// +optional
+	PersistentVolumeClaim *string `json:"persistentVolumeClaim,omitempty"`
+}
+
+// GitSubmodules describes which submodules of a Git repository are initialized.
+type GitSubmodules struct {
+	// Enabled defines whether submodules are initialized. Defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Paths restricts the initialized submodules to the ones with the given paths in the repository.
+	// Nested submodules of these are initialized up to the recursion depth.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// RecursionDepth limits how deep nested submodules are initialized, 1 only initializes the
+	// submodules of the repository itself. If not specified, nested submodules are initialized
+	// without a limit.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RecursionDepth *int `json:"recursionDepth,omitempty"`
+}
+
+// GitLFS describes which Git Large File Storage (LFS) files of a Git repository are fetched.
+type GitLFS struct {
+	// Enabled defines whether LFS files are fetched. If disabled, the LFS pointer files are checked
+	// out instead. Defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Include restricts the fetched LFS files to the ones matching the given patterns.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude skips fetching the LFS files matching the given patterns.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// The keys of the secret referenced by a GitVerify that hold the trusted keys and identities.
+const (
+	// GitVerifyGPGPublicKeysKey holds the ASCII-armored GPG public keys that are trusted.
+	GitVerifyGPGPublicKeysKey = "gpg-public-keys"
+
+	// GitVerifySSHAllowedSignersKey holds the trusted SSH keys in the allowed signers format of ssh-keygen.
+	GitVerifySSHAllowedSignersKey = "ssh-allowed-signers"
+
+	// GitVerifyGitsignIdentitiesKey holds the trusted gitsign identities, one `<identity> <oidc-issuer>`
+	// pair per line.
+	GitVerifyGitsignIdentitiesKey = "gitsign-identities"
+)
+
+// GitVerify describes how the signature of a Git commit or tag is verified.
+type GitVerify struct {
+	// Secret references a secret in the namespace of the Build that contains the trusted keys
+	// and identities. GPG public keys are read from the key `gpg-public-keys`, SSH allowed
+	// signers from `ssh-allowed-signers`, and gitsign identities from `gitsign-identities`.
+	// At least one of them must be present.
+	Secret string `json:"secret"`
+}
+
+// GitProvider enumerates the Git services whose API is supported.
+type GitProvider string
+
+const (
+	// GitHubProvider is the GitHub (or GitHub Enterprise) API
+	GitHubProvider GitProvider = "GitHub"
+
+	// GitLabProvider is the GitLab API
+	GitLabProvider GitProvider = "GitLab"
+
+	// GiteaProvider is the Gitea API
+	GiteaProvider GitProvider = "Gitea"
+
+	// BitbucketProvider is the Bitbucket Cloud API
+	BitbucketProvider GitProvider = "Bitbucket"
+)
+
+// GitStatusReportTokenKey is the key in the secret referenced by a GitStatusReport that holds the
+// API token.
+const GitStatusReportTokenKey = "token"
+
+// GitStatusReport describes how to report the status of BuildRuns to the Git service.
+type GitStatusReport struct {
+	// Provider is the Git service hosting the repository. Allowed values are
+	// `GitHub`, `GitLab`, `Gitea`, and `Bitbucket`.
+	//
+	// +kubebuilder:validation:Enum=GitHub;GitLab;Gitea;Bitbucket
+	Provider GitProvider `json:"provider"`
+
+	// Secret references a Secret that contains the API token under the key `token`.
+	Secret string `json:"secret"`
+
+	// APIURL is the base URL of the API of the Git service. It defaults to the API of
+	// the host of the repository URL, for example https://api.github.com for GitHub.
+	//
+	// +optional
+	APIURL *string `json:"apiURL,omitempty"`
+
+	// Context is the name of the commit status, it defaults to shipwright/<build name>.
+	//
+	// +optional
+	Context *string `json:"context,omitempty"`
 }
 
 // OCIArtifact describes how to obtain source code from a container image, also known as an OCI
@@ -93,12 +298,56 @@ type OCIArtifact struct {
 	//
 	// +optional
 	PullSecret *string `json:"pullSecret,omitempty"`
+
+	// Limits lowers the limits of the content of the image that the cluster administrator
+	// configured, higher limits are not valid. The BuildRun fails with the reason
+	// BundleLimitExceeded if the content exceeds one of them.
+	//
+	// +optional
+	Limits *BundleLimits `json:"limits,omitempty"`
+}
+
+// BundleLimits restricts the content of a source bundle that is unpacked, a limit that is not
+// defined defaults to the one that the cluster administrator configured and a limit cannot exceed it.
+type BundleLimits struct {
+	// MaxSize is the maximum total size of the unpacked files, for example 500Mi.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="quantity(string(self)).isGreaterThan(quantity('0'))",message="maxSize must be greater than 0"
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+
+	// MaxFileSize is the maximum size of a single unpacked file, for example 100Mi.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="quantity(string(self)).isGreaterThan(quantity('0'))",message="maxFileSize must be greater than 0"
+	MaxFileSize *resource.Quantity `json:"maxFileSize,omitempty"`
+
+	// MaxEntries is the maximum number of unpacked files and directories.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxEntries *int64 `json:"maxEntries,omitempty"`
+}
+
+// HTTP describes how to obtain source code from an archive that is downloaded from a HTTP(S) URL.
+type HTTP struct {
+	// URL is the location of the archive. Supported archive formats are `.tar.gz` (or `.tgz`),
+	// `.tar.zst` (or `.tzst`), and `.zip`, the format is determined by the file name extension.
+	URL string `json:"url"`
+
+	// Checksum is the expected checksum of the archive, prefixed with the algorithm, either
+	// `sha256:<hex>` or `sha512:<hex>`. If defined, the archive is only unpacked if its checksum
+	// matches.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(sha256:[0-9a-f]{64}|sha512:[0-9a-f]{128})$`
+	Checksum *string `json:"checksum,omitempty"`
 }
 
 // Source describes the source code to fetch for the build.
 type Source struct {
 	// Type is the type of source code used as input for the build. Allowed values are
-	// `Git`, `OCI`, and `Local`.
+	// `Git`, `OCI`, `HTTP`, and `Local`.
 	Type BuildSourceType `json:"type"`
 
 	// ContextDir is a path to a subdirectory within the source code that should be used as the
@@ -118,6 +367,12 @@ type Source struct {
 	// +optional
 	Git *Git `json:"git,omitempty"`
 
+	// HTTP contains the details for obtaining source code from an archive that is downloaded from
+	// a HTTP(S) URL.
+	//
+	// +optional
+	HTTP *HTTP `json:"http,omitempty"`
+
 	// Local contains the details for obtaining source code that is streamed in from a remote
 	// machine's local directory.
 	//
@@ -125,11 +380,66 @@ type Source struct {
 	Local *Local `json:"local,omitempty"`
 }
 
+// NamedSource describes an additional source that is fetched into a sub-directory of the source
+// workspace, next to the source code defined in `spec.source`.
+type NamedSource struct {
+	// Name identifies the source. It must be a DNS label that is unique among the sources, the
+	// names `default` and `local` are reserved.
+	Name string `json:"name"`
+
+	// Type is the type of the source. Allowed values are `Git`, `OCI`, and `HTTP`.
+	//
+	// +kubebuilder:validation:Enum=Git;OCI;HTTP
+	Type BuildSourceType `json:"type"`
+
+	// TargetDirectory is the relative path of the sub-directory of the source workspace that the
+	// source is fetched into. If not defined, it defaults to the name of the source.
+	//
+	// +optional
+	TargetDirectory *string `json:"targetDirectory,omitempty"`
+
+	// OCIArtifact contains the details for obtaining source code from a container image, also
+	// known as an OCI artifact.
+	//
+	// +optional
+	OCIArtifact *OCIArtifact `json:"ociArtifact,omitempty"`
+
+	// Git contains the details for obtaining source code from a git repository.
+	//
+	// +optional
+	Git *Git `json:"git,omitempty"`
+
+	// HTTP contains the details for obtaining source code from an archive that is downloaded from
+	// a HTTP(S) URL.
+	//
+	// +optional
+	HTTP *HTTP `json:"http,omitempty"`
+}
+
+// GetTargetDirectory returns the sub-directory of the source workspace that the source is fetched
+// into, which defaults to the name of the source.
+func (s *NamedSource) GetTargetDirectory() string {
+	if s.TargetDirectory != nil && *s.TargetDirectory != "" {
+		return *s.TargetDirectory
+	}
+
+	return s.Name
+}
+
+// BuildRunGit describes overrides for the Git source of the parent Build object.
+type BuildRunGit struct {
+	// Revision overrides the Git revision (e.g., branch, tag, commit SHA,
+	// etc.) defined in the Build.
+	//
+	// +optional
+	Revision *string `json:"revision,omitempty"`
+}
+
 // BuildRunSource describes the source to use in a BuildRun, overriding the value of the parent
 // Build object.
 type BuildRunSource struct {
 	// Type is the BuildRunSource qualifier, the type of the source.
-	// Only `Local` is supported.
+	// Allowed values are `Local` and `Git`.
 	//
 	Type BuildSourceType `json:"type"`
 
@@ -137,4 +447,10 @@ type BuildRunSource struct {
 	//
 	// +optional
 	Local *Local `json:"local,omitempty"`
+
+	// Git contains the overrides for the Git source of the Build, only
+	// applicable when the Build uses a source of type Git
+	//
+	// +optional
+	Git *BuildRunGit `json:"git,omitempty"`
 }
@@ -4,6 +4,10 @@
 
 package v1beta1
 
+// TriggerSecretTokenKey is the key in the secret referenced by TriggerSecret that holds the token
+// used to validate webhook requests.
+const TriggerSecretTokenKey = "token"
+
 // Trigger represents the webhook trigger configuration for a Build.
 type Trigger struct {
 	// When the list of scenarios when a new build should take place.
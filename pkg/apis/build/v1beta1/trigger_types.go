@@ -4,6 +4,8 @@
 
 package v1beta1
 
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 // TriggerType set of TriggerWhen valid names.
 type TriggerType string
 
@@ -16,9 +18,22 @@ const (
 
 	// PipelineTrigger Tekton Pipeline trigger type name.
 	PipelineTrigger TriggerType = "Pipeline"
+
+	// GitLabWebHookTrigger GitLabWebHookTrigger trigger type name.
+	GitLabWebHookTrigger TriggerType = "GitLab"
+
+	// GiteaWebHookTrigger GiteaWebHookTrigger trigger type name.
+	GiteaWebHookTrigger TriggerType = "Gitea"
+
+	// BitbucketWebHookTrigger BitbucketWebHookTrigger trigger type name.
+	BitbucketWebHookTrigger TriggerType = "Bitbucket"
+
+	// ScheduleTrigger Schedule (cron) trigger type name.
+	ScheduleTrigger TriggerType = "Schedule"
 )
 
 // GitHubEventName set of WhenGitHub valid event names.
+// +kubebuilder:validation:Enum=PullRequest;Push;Tag;Release
 type GitHubEventName string
 
 const (
@@ -27,6 +42,57 @@ const (
 
 	// GitHubPushEvent git push webhook event name.
 	GitHubPushEvent GitHubEventName = "Push"
+
+	// GitHubTagEvent git tag push webhook event name.
+	GitHubTagEvent GitHubEventName = "Tag"
+
+	// GitHubReleaseEvent github published release event name.
+	GitHubReleaseEvent GitHubEventName = "Release"
+)
+
+// GitLabEventName set of WhenGitLab valid event names.
+// +kubebuilder:validation:Enum=Push;MergeRequest;TagPush
+type GitLabEventName string
+
+const (
+	// GitLabPushEvent gitlab push hook event name.
+	GitLabPushEvent GitLabEventName = "Push"
+
+	// GitLabMergeRequestEvent gitlab merge-request hook event name.
+	GitLabMergeRequestEvent GitLabEventName = "MergeRequest"
+
+	// GitLabTagPushEvent gitlab tag push hook event name.
+	GitLabTagPushEvent GitLabEventName = "TagPush"
+)
+
+// GiteaEventName set of WhenGitea valid event names.
+// +kubebuilder:validation:Enum=Push;PullRequest;Tag
+type GiteaEventName string
+
+const (
+	// GiteaPushEvent gitea push webhook event name.
+	GiteaPushEvent GiteaEventName = "Push"
+
+	// GiteaPullRequestEvent gitea pull-request webhook event name.
+	GiteaPullRequestEvent GiteaEventName = "PullRequest"
+
+	// GiteaTagEvent gitea tag push webhook event name.
+	GiteaTagEvent GiteaEventName = "Tag"
+)
+
+// BitbucketEventName set of WhenBitbucket valid event names.
+// +kubebuilder:validation:Enum=Push;PullRequest;Tag
+type BitbucketEventName string
+
+const (
+	// BitbucketPushEvent bitbucket repository push webhook event name.
+	BitbucketPushEvent BitbucketEventName = "Push"
+
+	// BitbucketPullRequestEvent bitbucket pull-request webhook event name.
+	BitbucketPullRequestEvent BitbucketEventName = "PullRequest"
+
+	// BitbucketTagEvent bitbucket tag push webhook event name.
+	BitbucketTagEvent BitbucketEventName = "Tag"
 )
 
 // WhenImage attributes to match Image events.
@@ -35,6 +101,32 @@ type WhenImage struct {
 	//
 	// +optional
 	Names []string `json:"names,omitempty"`
+
+	// PullSecret references a Secret that contains credentials to access the image registry.
+	//
+	// +optional
+	PullSecret *string `json:"pullSecret,omitempty"`
+
+	// Insecure defines whether the registry is not secure
+	//
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+}
+
+// ImageTriggerStatus holds the digest of a watched image at the time it was last checked.
+type ImageTriggerStatus struct {
+	// Name the image name as defined in the trigger.
+	Name string `json:"name"`
+
+	// Digest the last seen digest of the image.
+	//
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// LastCheckTime the time the image was last resolved.
+	//
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
 }
 
 // WhenGitHub attributes to match GitHub events.
@@ -48,6 +140,181 @@ type WhenGitHub struct {
 	//
 	// +optional
 	Branches []string `json:"branches,omitempty"`
+
+	// Tags slice of tag names where the tag and release events apply, glob patterns like "v*" are supported.
+	// When empty, all tags match.
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Paths slice of glob patterns of which at least one must match a changed file of the event.
+	// Defaults to the context directory of the source.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// IgnorePaths slice of glob patterns of changed files that do not trigger a build.
+	//
+	// +optional
+	IgnorePaths []string `json:"ignorePaths,omitempty"`
+
+	// AllowForks defines whether pull-requests from forks of the repository trigger the build. The
+	// BuildRun builds the code of the fork and pushes it as output image. Defaults to false.
+	//
+	// +optional
+	AllowForks *bool `json:"allowForks,omitempty"`
+}
+
+// WhenGitLab attributes to match GitLab events.
+type WhenGitLab struct {
+	// Events GitLab event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GitLabEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Tags slice of tag names where the tag and release events apply, glob patterns like "v*" are supported.
+	// When empty, all tags match.
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Paths slice of glob patterns of which at least one must match a changed file of the event.
+	// Defaults to the context directory of the source.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// IgnorePaths slice of glob patterns of changed files that do not trigger a build.
+	//
+	// +optional
+	IgnorePaths []string `json:"ignorePaths,omitempty"`
+
+	// AllowForks defines whether pull-requests from forks of the repository trigger the build. The
+	// BuildRun builds the code of the fork and pushes it as output image. Defaults to false.
+	//
+	// +optional
+	AllowForks *bool `json:"allowForks,omitempty"`
+}
+
+// WhenGitea attributes to match Gitea events.
+type WhenGitea struct {
+	// Events Gitea event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GiteaEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Tags slice of tag names where the tag and release events apply, glob patterns like "v*" are supported.
+	// When empty, all tags match.
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Paths slice of glob patterns of which at least one must match a changed file of the event.
+	// Defaults to the context directory of the source.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// IgnorePaths slice of glob patterns of changed files that do not trigger a build.
+	//
+	// +optional
+	IgnorePaths []string `json:"ignorePaths,omitempty"`
+
+	// AllowForks defines whether pull-requests from forks of the repository trigger the build. The
+	// BuildRun builds the code of the fork and pushes it as output image. Defaults to false.
+	//
+	// +optional
+	AllowForks *bool `json:"allowForks,omitempty"`
+}
+
+// WhenBitbucket attributes to match Bitbucket events.
+type WhenBitbucket struct {
+	// Events Bitbucket event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []BitbucketEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Tags slice of tag names where the tag and release events apply, glob patterns like "v*" are supported.
+	// When empty, all tags match.
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Paths slice of glob patterns of which at least one must match a changed file of the event.
+	// Defaults to the context directory of the source.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// IgnorePaths slice of glob patterns of changed files that do not trigger a build.
+	//
+	// +optional
+	IgnorePaths []string `json:"ignorePaths,omitempty"`
+
+	// AllowForks defines whether pull-requests from forks of the repository trigger the build. The
+	// BuildRun builds the code of the fork and pushes it as output image. Defaults to false.
+	//
+	// +optional
+	AllowForks *bool `json:"allowForks,omitempty"`
+}
+
+// ScheduleConcurrencyPolicy set of WhenSchedule valid concurrency policies.
+type ScheduleConcurrencyPolicy string
+
+const (
+	// ScheduleConcurrencyAllow starts a new BuildRun even when a previous one is still active.
+	ScheduleConcurrencyAllow ScheduleConcurrencyPolicy = "Allow"
+
+	// ScheduleConcurrencySkip skips the scheduled BuildRun when a previous one is still active.
+	ScheduleConcurrencySkip ScheduleConcurrencyPolicy = "Skip"
+
+	// ScheduleConcurrencyReplace cancels the active BuildRuns before starting the scheduled one.
+	ScheduleConcurrencyReplace ScheduleConcurrencyPolicy = "Replace"
+)
+
+// WhenSchedule attributes to trigger builds periodically.
+type WhenSchedule struct {
+	// Cron the schedule in the standard cron format with five fields, for example "0 3 * * *".
+	Cron string `json:"cron"`
+
+	// TimeZone the name of the time zone the schedule is interpreted in, for example "Europe/Berlin".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy defines what happens when a previous BuildRun of the Build is still active
+	// when the schedule is due. Valid values are "Allow", "Skip" and "Replace", defaults to "Allow".
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Allow;Skip;Replace
+	ConcurrencyPolicy *ScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+}
+
+// ScheduleTriggerStatus holds the time a Schedule trigger last created a BuildRun.
+type ScheduleTriggerStatus struct {
+	// Name the name of the trigger condition.
+	Name string `json:"name"`
+
+	// LastScheduleTime the time the schedule was last due, or the time the trigger was first observed.
+	//
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
 }
 
 // WhenObjectRef attributes to reference local Kubernetes objects.
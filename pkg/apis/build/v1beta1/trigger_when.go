@@ -17,6 +17,21 @@ type TriggerWhen struct {
 	// +optional
 	GitHub *WhenGitHub `json:"github,omitempty"`
 
+	// GitLab describes how to trigger builds based on GitLab (SCM) events.
+	//
+	// +optional
+	GitLab *WhenGitLab `json:"gitlab,omitempty"`
+
+	// Gitea describes how to trigger builds based on Gitea (SCM) events.
+	//
+	// +optional
+	Gitea *WhenGitea `json:"gitea,omitempty"`
+
+	// Bitbucket describes how to trigger builds based on Bitbucket (SCM) events.
+	//
+	// +optional
+	Bitbucket *WhenBitbucket `json:"bitbucket,omitempty"`
+
 	// Image slice of image names where the event applies.
 	//
 	// +optional
@@ -27,6 +42,17 @@ type TriggerWhen struct {
 	//
 	// +optional
 	ObjectRef *WhenObjectRef `json:"objectRef,omitempty"`
+
+	// TagOutputImage defines whether BuildRuns triggered by tag or release events push the output
+	// image with the Git tag name as image tag, for example registry/app:v1.4.2 for the tag v1.4.2.
+	//
+	// +optional
+	TagOutputImage *bool `json:"tagOutputImage,omitempty"`
+
+	// Schedule describes how to trigger builds periodically.
+	//
+	// +optional
+	Schedule *WhenSchedule `json:"schedule,omitempty"`
 }
 
 // GetBranches return a slice of branch names based on the WhenTypeName informed.
@@ -37,6 +63,149 @@ func (w *TriggerWhen) GetBranches(whenType TriggerType) []string {
 			return nil
 		}
 		return w.GitHub.Branches
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Branches
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Branches
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Branches
+	}
+	return nil
+}
+
+// GetTags return a slice of tag names based on the WhenTypeName informed.
+func (w *TriggerWhen) GetTags(whenType TriggerType) []string {
+	switch whenType {
+	case GitHubWebHookTrigger:
+		if w.GitHub == nil {
+			return nil
+		}
+		return w.GitHub.Tags
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Tags
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Tags
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Tags
+	}
+	return nil
+}
+
+// GetPaths return a slice of changed file patterns based on the WhenTypeName informed.
+func (w *TriggerWhen) GetPaths(whenType TriggerType) []string {
+	switch whenType {
+	case GitHubWebHookTrigger:
+		if w.GitHub == nil {
+			return nil
+		}
+		return w.GitHub.Paths
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Paths
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Paths
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Paths
+	}
+	return nil
+}
+
+// GetIgnorePaths return a slice of ignored changed file patterns based on the WhenTypeName informed.
+func (w *TriggerWhen) GetIgnorePaths(whenType TriggerType) []string {
+	switch whenType {
+	case GitHubWebHookTrigger:
+		if w.GitHub == nil {
+			return nil
+		}
+		return w.GitHub.IgnorePaths
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.IgnorePaths
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.IgnorePaths
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.IgnorePaths
 	}
 	return nil
 }
+
+// GetEvents return a slice of event names based on the WhenTypeName informed.
+func (w *TriggerWhen) GetEvents(whenType TriggerType) []string {
+	var events []string
+	switch whenType {
+	case GitHubWebHookTrigger:
+		if w.GitHub != nil {
+			for _, event := range w.GitHub.Events {
+				events = append(events, string(event))
+			}
+		}
+	case GitLabWebHookTrigger:
+		if w.GitLab != nil {
+			for _, event := range w.GitLab.Events {
+				events = append(events, string(event))
+			}
+		}
+	case GiteaWebHookTrigger:
+		if w.Gitea != nil {
+			for _, event := range w.Gitea.Events {
+				events = append(events, string(event))
+			}
+		}
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket != nil {
+			for _, event := range w.Bitbucket.Events {
+				events = append(events, string(event))
+			}
+		}
+	}
+	return events
+}
+
+// AllowsForks returns whether pull-requests and merge-requests from forks match based on the WhenTypeName informed.
+func (w *TriggerWhen) AllowsForks(whenType TriggerType) bool {
+	switch whenType {
+	case GitHubWebHookTrigger:
+		return w.GitHub != nil && w.GitHub.AllowForks != nil && *w.GitHub.AllowForks
+	case GitLabWebHookTrigger:
+		return w.GitLab != nil && w.GitLab.AllowForks != nil && *w.GitLab.AllowForks
+	case GiteaWebHookTrigger:
+		return w.Gitea != nil && w.Gitea.AllowForks != nil && *w.Gitea.AllowForks
+	case BitbucketWebHookTrigger:
+		return w.Bitbucket != nil && w.Bitbucket.AllowForks != nil && *w.Bitbucket.AllowForks
+	}
+	return false
+}
@@ -76,6 +76,22 @@ func (in *BuildList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildNetwork) DeepCopyInto(out *BuildNetwork) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildNetwork.
+func (in *BuildNetwork) DeepCopy() *BuildNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildRetention) DeepCopyInto(out *BuildRetention) {
 	*out = *in
@@ -145,6 +161,27 @@ func (in *BuildRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRunGit) DeepCopyInto(out *BuildRunGit) {
+	*out = *in
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRunGit.
+func (in *BuildRunGit) DeepCopy() *BuildRunGit {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRunGit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildRunList) DeepCopyInto(out *BuildRunList) {
 	*out = *in
@@ -212,6 +249,11 @@ func (in *BuildRunSource) DeepCopyInto(out *BuildRunSource) {
 		*out = new(Local)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(BuildRunGit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -287,6 +329,18 @@ func (in *BuildRunSpec) DeepCopyInto(out *BuildRunSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SchedulerName != nil {
+		in, out := &in.SchedulerName, &out.SchedulerName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -308,6 +362,18 @@ func (in *BuildRunStatus) DeepCopyInto(out *BuildRunStatus) {
 		*out = new(SourceResult)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]NamedSourceResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResolvedGitRevision != nil {
+		in, out := &in.ResolvedGitRevision, &out.ResolvedGitRevision
+		*out = new(ResolvedGitRevision)
+		**out = **in
+	}
 	if in.Output != nil {
 		in, out := &in.Output, &out.Output
 		*out = new(Output)
@@ -364,6 +430,13 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(Source)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]NamedSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Trigger != nil {
 		in, out := &in.Trigger, &out.Trigger
 		*out = new(Trigger)
@@ -409,6 +482,23 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SchedulerName != nil {
+		in, out := &in.SchedulerName, &out.SchedulerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(BuildNetwork)
+		**out = **in
+	}
 	return
 }
 
@@ -440,6 +530,20 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImageTriggers != nil {
+		in, out := &in.ImageTriggers, &out.ImageTriggers
+		*out = make([]ImageTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScheduleTriggers != nil {
+		in, out := &in.ScheduleTriggers, &out.ScheduleTriggers
+		*out = make([]ScheduleTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -632,6 +736,37 @@ func (in *BuildVolume) DeepCopy() *BuildVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleLimits) DeepCopyInto(out *BundleLimits) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFileSize != nil {
+		in, out := &in.MaxFileSize, &out.MaxFileSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleLimits.
+func (in *BundleLimits) DeepCopy() *BundleLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterBuildStrategy) DeepCopyInto(out *ClusterBuildStrategy) {
 	*out = *in
@@ -761,11 +896,61 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Refspec != nil {
+		in, out := &in.Refspec, &out.Refspec
+		*out = new(string)
+		**out = **in
+	}
 	if in.CloneSecret != nil {
 		in, out := &in.CloneSecret, &out.CloneSecret
 		*out = new(string)
 		**out = **in
 	}
+	if in.CloneSecrets != nil {
+		in, out := &in.CloneSecrets, &out.CloneSecrets
+		*out = make([]GitCloneSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int)
+		**out = **in
+	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Submodules != nil {
+		in, out := &in.Submodules, &out.Submodules
+		*out = new(GitSubmodules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LFS != nil {
+		in, out := &in.LFS, &out.LFS
+		*out = new(GitLFS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(GitVerify)
+		**out = **in
+	}
+	if in.StatusReport != nil {
+		in, out := &in.StatusReport, &out.StatusReport
+		*out = new(GitStatusReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(GitCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -779,9 +964,91 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCache) DeepCopyInto(out *GitCache) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCache.
+func (in *GitCache) DeepCopy() *GitCache {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCloneSecret) DeepCopyInto(out *GitCloneSecret) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCloneSecret.
+func (in *GitCloneSecret) DeepCopy() *GitCloneSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCloneSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLFS) DeepCopyInto(out *GitLFS) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLFS.
+func (in *GitLFS) DeepCopy() *GitLFS {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLFS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSourceResult) DeepCopyInto(out *GitSourceResult) {
 	*out = *in
+	if in.CommitterDate != nil {
+		in, out := &in.CommitterDate, &out.CommitterDate
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -795,6 +1062,116 @@ func (in *GitSourceResult) DeepCopy() *GitSourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitStatusReport) DeepCopyInto(out *GitStatusReport) {
+	*out = *in
+	if in.APIURL != nil {
+		in, out := &in.APIURL, &out.APIURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitStatusReport.
+func (in *GitStatusReport) DeepCopy() *GitStatusReport {
+	if in == nil {
+		return nil
+	}
+	out := new(GitStatusReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSubmodules) DeepCopyInto(out *GitSubmodules) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecursionDepth != nil {
+		in, out := &in.RecursionDepth, &out.RecursionDepth
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSubmodules.
+func (in *GitSubmodules) DeepCopy() *GitSubmodules {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSubmodules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitVerify) DeepCopyInto(out *GitVerify) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitVerify.
+func (in *GitVerify) DeepCopy() *GitVerify {
+	if in == nil {
+		return nil
+	}
+	out := new(GitVerify)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP) DeepCopyInto(out *HTTP) {
+	*out = *in
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP.
+func (in *HTTP) DeepCopy() *HTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSourceResult) DeepCopyInto(out *HTTPSourceResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSourceResult.
+func (in *HTTPSourceResult) DeepCopy() *HTTPSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -845,6 +1222,26 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTriggerStatus) DeepCopyInto(out *ImageTriggerStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTriggerStatus.
+func (in *ImageTriggerStatus) DeepCopy() *ImageTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Local) DeepCopyInto(out *Local) {
 	*out = *in
@@ -882,6 +1279,59 @@ func (in *Location) DeepCopy() *Location {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedSource) DeepCopyInto(out *NamedSource) {
+	*out = *in
+	if in.TargetDirectory != nil {
+		in, out := &in.TargetDirectory, &out.TargetDirectory
+		*out = new(string)
+		**out = **in
+	}
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(OCIArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(Git)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedSource.
+func (in *NamedSource) DeepCopy() *NamedSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedSourceResult) DeepCopyInto(out *NamedSourceResult) {
+	*out = *in
+	in.SourceResult.DeepCopyInto(&out.SourceResult)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedSourceResult.
+func (in *NamedSourceResult) DeepCopy() *NamedSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedSourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 	*out = *in
@@ -895,6 +1345,11 @@ func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(BundleLimits)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1050,6 +1505,42 @@ func (in *ReferencedBuild) DeepCopy() *ReferencedBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedGitRevision) DeepCopyInto(out *ResolvedGitRevision) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedGitRevision.
+func (in *ResolvedGitRevision) DeepCopy() *ResolvedGitRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedGitRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleTriggerStatus) DeepCopyInto(out *ScheduleTriggerStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleTriggerStatus.
+func (in *ScheduleTriggerStatus) DeepCopy() *ScheduleTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleValue) DeepCopyInto(out *SingleValue) {
 	*out = *in
@@ -1099,6 +1590,11 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(Git)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Local != nil {
 		in, out := &in.Local, &out.Local
 		*out = new(Local)
@@ -1123,13 +1619,18 @@ func (in *SourceResult) DeepCopyInto(out *SourceResult) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitSourceResult)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OciArtifact != nil {
 		in, out := &in.OciArtifact, &out.OciArtifact
 		*out = new(OciArtifactSourceResult)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSourceResult)
+		**out = **in
+	}
 	if in.Timestamp != nil {
 		in, out := &in.Timestamp, &out.Timestamp
 		*out = (*in).DeepCopy()
@@ -1250,6 +1751,21 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenGitHub)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(WhenGitLab)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(WhenGitea)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(WhenBitbucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(WhenImage)
@@ -1260,6 +1776,16 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenObjectRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TagOutputImage != nil {
+		in, out := &in.TagOutputImage, &out.TagOutputImage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WhenSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1341,6 +1867,52 @@ func (in *VulnerabilityScanOptions) DeepCopy() *VulnerabilityScanOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenBitbucket) DeepCopyInto(out *WhenBitbucket) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]BitbucketEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePaths != nil {
+		in, out := &in.IgnorePaths, &out.IgnorePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowForks != nil {
+		in, out := &in.AllowForks, &out.AllowForks
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenBitbucket.
+func (in *WhenBitbucket) DeepCopy() *WhenBitbucket {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenBitbucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 	*out = *in
@@ -1354,6 +1926,26 @@ func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePaths != nil {
+		in, out := &in.IgnorePaths, &out.IgnorePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowForks != nil {
+		in, out := &in.AllowForks, &out.AllowForks
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1367,6 +1959,98 @@ func (in *WhenGitHub) DeepCopy() *WhenGitHub {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitLab) DeepCopyInto(out *WhenGitLab) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GitLabEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePaths != nil {
+		in, out := &in.IgnorePaths, &out.IgnorePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowForks != nil {
+		in, out := &in.AllowForks, &out.AllowForks
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitLab.
+func (in *WhenGitLab) DeepCopy() *WhenGitLab {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitLab)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitea) DeepCopyInto(out *WhenGitea) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GiteaEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnorePaths != nil {
+		in, out := &in.IgnorePaths, &out.IgnorePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowForks != nil {
+		in, out := &in.AllowForks, &out.AllowForks
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitea.
+func (in *WhenGitea) DeepCopy() *WhenGitea {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitea)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 	*out = *in
@@ -1375,6 +2059,16 @@ func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullSecret != nil {
+		in, out := &in.PullSecret, &out.PullSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -1415,3 +2109,29 @@ func (in *WhenObjectRef) DeepCopy() *WhenObjectRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenSchedule) DeepCopyInto(out *WhenSchedule) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConcurrencyPolicy != nil {
+		in, out := &in.ConcurrencyPolicy, &out.ConcurrencyPolicy
+		*out = new(ScheduleConcurrencyPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenSchedule.
+func (in *WhenSchedule) DeepCopy() *WhenSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,250 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ArchiveFormat is the format of a source code archive
+type ArchiveFormat string
+
+const (
+	// ArchiveTarGz is a gzip compressed tarball
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+
+	// ArchiveTarZstd is a zstd compressed tarball
+	ArchiveTarZstd ArchiveFormat = "tar.zst"
+
+	// ArchiveZip is a zip archive
+	ArchiveZip ArchiveFormat = "zip"
+)
+
+// archiveExtensions maps file name extensions to archive formats
+var archiveExtensions = []struct {
+	extension string
+	format    ArchiveFormat
+}{
+	{".tar.gz", ArchiveTarGz},
+	{".tgz", ArchiveTarGz},
+	{".tar.zst", ArchiveTarZstd},
+	{".tzst", ArchiveTarZstd},
+	{".zip", ArchiveZip},
+}
+
+// ArchiveFormatOf returns the format of the archive at the given URL based on the file name extension
+func ArchiveFormatOf(archiveURL string) (ArchiveFormat, error) {
+	u, err := url.Parse(archiveURL)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := strings.ToLower(path.Base(u.Path))
+	for _, entry := range archiveExtensions {
+		if strings.HasSuffix(fileName, entry.extension) {
+			return entry.format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported archive %q, supported are .tar.gz, .tgz, .tar.zst, .tzst, and .zip files", fileName)
+}
+
+// Checksum is a parsed checksum of the form <algorithm>:<hex>
+type Checksum struct {
+	Algorithm string
+	Value     string
+}
+
+// ParseChecksum parses a checksum of the form sha256:<hex> or sha512:<hex>
+func ParseChecksum(checksum string) (*Checksum, error) {
+	algorithm, value, found := strings.Cut(checksum, ":")
+	if !found {
+		return nil, fmt.Errorf("checksum %q has no algorithm prefix, expected sha256:<hex> or sha512:<hex>", checksum)
+	}
+
+	if _, err := newHash(algorithm); err != nil {
+		return nil, err
+	}
+
+	if _, err := hex.DecodeString(value); err != nil {
+		return nil, fmt.Errorf("checksum %q is not hex encoded: %w", checksum, err)
+	}
+
+	return &Checksum{Algorithm: algorithm, Value: strings.ToLower(value)}, nil
+}
+
+func (c *Checksum) String() string {
+	return c.Algorithm + ":" + c.Value
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q, supported are sha256 and sha512", algorithm)
+	}
+}
+
+// DownloadDetails contains details about a downloaded archive
+type DownloadDetails struct {
+	// Digest is the sha256 digest of the archive
+	Digest string
+}
+
+// Download fetches the archive from the URL into the file. If a checksum is given, the download fails
+// when the checksum of the archive does not match. The download stops with a LimitExceededError as
+// soon as the archive exceeds the maximum size in bytes, a maximum size of zero means unlimited.
+func Download(ctx context.Context, client *http.Client, archiveURL string, file io.Writer, checksum *Checksum, maxSize int64) (*DownloadDetails, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download of %s failed with status %d", req.URL.Redacted(), resp.StatusCode)
+	}
+
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return nil, limitExceeded("archive %s has a size of %d bytes, which exceeds the maximum size of %d bytes", req.URL.Redacted(), resp.ContentLength, maxSize)
+	}
+
+	digest := sha256.New()
+	writers := []io.Writer{file, digest}
+
+	var verify hash.Hash
+	if checksum != nil {
+		if verify, err = newHash(checksum.Algorithm); err != nil {
+			return nil, err
+		}
+		writers = append(writers, verify)
+	}
+
+	// read one byte more than allowed to detect archives that exceed the maximum size
+	var body io.Reader = resp.Body
+	if maxSize > 0 {
+		body = io.LimitReader(resp.Body, maxSize+1)
+	}
+
+	written, err := io.Copy(io.MultiWriter(writers...), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize > 0 && written > maxSize {
+		return nil, limitExceeded("archive %s exceeds the maximum size of %d bytes", req.URL.Redacted(), maxSize)
+	}
+
+	if verify != nil {
+		if actual := hex.EncodeToString(verify.Sum(nil)); actual != checksum.Value {
+			return nil, fmt.Errorf("checksum mismatch for %s, expected %s but got %s:%s", req.URL.Redacted(), checksum, checksum.Algorithm, actual)
+		}
+	}
+
+	return &DownloadDetails{Digest: "sha256:" + hex.EncodeToString(digest.Sum(nil))}, nil
+}
+
+// UnpackArchive extracts the archive file of the given format into the target path, using the same
+// rules and limits as UnpackWithLimits
+func UnpackArchive(file *os.File, format ArchiveFormat, targetPath string, limits UnpackLimits) (*UnpackDetails, error) {
+	switch format {
+	case ArchiveTarGz:
+		gr, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+
+		return UnpackWithLimits(gr, targetPath, limits)
+
+	case ArchiveTarZstd:
+		zr, err := zstd.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+
+		return UnpackWithLimits(zr, targetPath, limits)
+
+	case ArchiveZip:
+		stat, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+
+		zr, err := zip.NewReader(file, stat.Size())
+		if err != nil {
+			return nil, err
+		}
+
+		// convert the zip archive into a tar stream, so that the same extraction rules apply
+		pr, pw := io.Pipe()
+		go func() { pw.CloseWithError(zipToTar(zr, pw)) }()
+		defer pr.Close()
+
+		return UnpackWithLimits(pr, targetPath, limits)
+
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+// zipToTar writes the entries of the zip archive as tar stream
+func zipToTar(zr *zip.Reader, out io.Writer) error {
+	tw := tar.NewWriter(out)
+	for _, f := range zr.File {
+		header, err := tar.FileInfoHeader(f.FileInfo(), "")
+		if err != nil {
+			return err
+		}
+		header.Name = f.Name
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		// #nosec G110 the size of the entry is limited by the tar header written above
+		_, err = io.Copy(tw, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
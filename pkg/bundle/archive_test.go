@@ -0,0 +1,147 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/shipwright-io/build/pkg/bundle"
+)
+
+var _ = Describe("Archive", func() {
+	Context("determining the archive format", func() {
+		It("should use the file name extension of the URL", func() {
+			Expect(ArchiveFormatOf("https://example.com/release/v1.0.0/app-1.0.0.tar.gz")).To(Equal(ArchiveTarGz))
+			Expect(ArchiveFormatOf("https://example.com/app-1.0.0.tgz?token=abc")).To(Equal(ArchiveTarGz))
+			Expect(ArchiveFormatOf("https://example.com/app-1.0.0.tar.zst")).To(Equal(ArchiveTarZstd))
+			Expect(ArchiveFormatOf("https://example.com/APP-1.0.0.ZIP")).To(Equal(ArchiveZip))
+		})
+
+		It("should fail for unsupported formats", func() {
+			_, err := ArchiveFormatOf("https://example.com/app-1.0.0.tar.bz2")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("parsing checksums", func() {
+		It("should parse sha256 and sha512 checksums", func() {
+			checksum, err := ParseChecksum("sha256:3B4C")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checksum.Algorithm).To(Equal("sha256"))
+			Expect(checksum.String()).To(Equal("sha256:3b4c"))
+
+			_, err = ParseChecksum("sha512:3b4c")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail for unsupported algorithms or values", func() {
+			_, err := ParseChecksum("md5:3b4c")
+			Expect(err).To(HaveOccurred())
+
+			_, err = ParseChecksum("3b4c")
+			Expect(err).To(HaveOccurred())
+
+			_, err = ParseChecksum("sha256:xyz")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("downloading and unpacking", func() {
+		var tarball []byte
+
+		BeforeEach(func() {
+			var buf bytes.Buffer
+			zw, err := zstd.NewWriter(&buf)
+			Expect(err).ToNot(HaveOccurred())
+
+			tw := tar.NewWriter(zw)
+			// git archive writes a global header with the commit as first entry
+			Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeXGlobalHeader, Name: "pax_global_header", PAXRecords: map[string]string{"comment": "4f0b6dbb1e2c"}})).To(Succeed())
+			Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "app/README.md", Mode: 0644, Size: 5})).To(Succeed())
+			_, err = tw.Write([]byte("hello"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tw.Close()).To(Succeed())
+			Expect(zw.Close()).To(Succeed())
+
+			tarball = buf.Bytes()
+		})
+
+		It("should download and unpack a tar.zst archive", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(tarball)
+			}))
+			defer server.Close()
+
+			tempDir, err := os.MkdirTemp("", "archive")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+
+			file, err := os.Create(filepath.Join(tempDir, "app.tar.zst"))
+			Expect(err).ToNot(HaveOccurred())
+			defer file.Close()
+
+			details, err := Download(context.TODO(), http.DefaultClient, server.URL+"/app.tar.zst", file, nil, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(details.Digest).To(HavePrefix("sha256:"))
+
+			_, err = file.Seek(0, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = UnpackArchive(file, ArchiveTarZstd, filepath.Join(tempDir, "source"), UnpackLimits{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.ReadFile(filepath.Join(tempDir, "source", "app", "README.md"))).To(Equal([]byte("hello")))
+		})
+
+		It("should fail when the server does not return the archive", func() {
+			server := httptest.NewServer(http.NotFoundHandler())
+			defer server.Close()
+
+			_, err := Download(context.TODO(), http.DefaultClient, server.URL+"/app.tar.zst", &bytes.Buffer{}, nil, 0)
+			Expect(err).To(MatchError(ContainSubstring("failed with status 404")))
+		})
+
+		It("should stop the download when the archive exceeds the maximum size", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				// stream the archive without a content length
+				w.(http.Flusher).Flush()
+				_, _ = w.Write(tarball)
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			_, err := Download(context.TODO(), http.DefaultClient, server.URL+"/app.tar.zst", &buf, nil, 10)
+
+			var limitErr *LimitExceededError
+			Expect(errors.As(err, &limitErr)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("exceeds the maximum size of 10 bytes")))
+			Expect(buf.Len()).To(BeNumerically("<=", 11))
+		})
+
+		It("should fail before the download when the content length exceeds the maximum size", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(tarball)
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			_, err := Download(context.TODO(), http.DefaultClient, server.URL+"/app.tar.zst", &buf, nil, 10)
+
+			var limitErr *LimitExceededError
+			Expect(errors.As(err, &limitErr)).To(BeTrue())
+			Expect(buf.Len()).To(BeZero())
+		})
+	})
+})
@@ -7,6 +7,7 @@ package bundle
 import (
 	"archive/tar"
 	"bufio"
+	"compress/gzip"
 	"fmt"
 	"io"
 	"io/fs"
@@ -27,16 +28,100 @@ import (
 
 const shpIgnoreFilename = ".shpignore"
 
+// reproducibleCompressionLevel is the gzip compression level of reproducible bundle images, it is
+// pinned to an explicit level so that the digest does not change with the default of a library
+const reproducibleCompressionLevel = gzip.BestSpeed
+
+// PackOptions controls how a directory is packed into a tar stream
+type PackOptions struct {
+	// Reproducible normalizes the tar stream, so that identical directories result in identical
+	// tar streams and bundle image digests: the modification times are set to SourceDateEpoch, the
+	// owner is root, and the permissions are 0755 for directories and executable files, and 0644
+	// for other files
+	Reproducible bool
+
+	// SourceDateEpoch is the modification time of all entries of a reproducible tar stream, it
+	// defaults to the Unix epoch
+	SourceDateEpoch time.Time
+}
+
+// normalize sets the fields of the tar header that depend on the file system instead of the content
+func (o PackOptions) normalize(header *tar.Header) {
+	if !o.Reproducible {
+		return
+	}
+
+	modTime := time.Unix(0, 0)
+	if !o.SourceDateEpoch.IsZero() {
+		modTime = o.SourceDateEpoch
+	}
+
+	header.ModTime = modTime.UTC()
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+	header.Format = tar.FormatPAX
+
+	switch {
+	case header.Typeflag == tar.TypeDir, header.Mode&0111 != 0:
+		header.Mode = 0755
+
+	default:
+		header.Mode = 0644
+	}
+}
+
 // UnpackDetails contains details about the files that were unpacked
 type UnpackDetails struct {
 	MostRecentFileTimestamp *time.Time
 }
 
+// UnpackLimits restricts the content of a tar stream that is unpacked, so that a bundle cannot fill
+// the file system of the step, a limit of zero means that there is no limit
+type UnpackLimits struct {
+	// MaxSize is the maximum total size of all files in bytes
+	MaxSize int64
+
+	// MaxFileSize is the maximum size of a single file in bytes
+	MaxFileSize int64
+
+	// MaxEntries is the maximum number of files and directories
+	MaxEntries int64
+}
+
+// LimitExceededReason is the failure reason of a BuildRun whose source bundle exceeds one of the UnpackLimits
+const LimitExceededReason = "BundleLimitExceeded"
+
+// LimitExceededError is returned when the content of a tar stream exceeds one of the UnpackLimits
+type LimitExceededError struct {
+	message string
+}
+
+func (e *LimitExceededError) Error() string {
+	return e.message
+}
+
+func limitExceeded(format string, a ...any) error {
+	return &LimitExceededError{message: fmt.Sprintf(format, a...)}
+}
+
 // PackAndPush a local directory as-is into a container image. See
 // remote.Option for optional options to the image push to the registry, for
 // example to provide the appropriate access credentials.
 func PackAndPush(ref name.Reference, directory string, options ...remote.Option) (name.Digest, error) {
-	bundleLayer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return Pack(directory) })
+	return PackAndPushWithOptions(ref, directory, PackOptions{}, options...)
+}
+
+// PackAndPushWithOptions works like PackAndPush, but packs the directory using the pack options. The
+// layer of a reproducible bundle image is compressed with a fixed compression level.
+func PackAndPushWithOptions(ref name.Reference, directory string, packOptions PackOptions, options ...remote.Option) (name.Digest, error) {
+	var layerOptions []tarball.LayerOption
+	if packOptions.Reproducible {
+		layerOptions = append(layerOptions, tarball.WithCompressionLevel(reproducibleCompressionLevel))
+	}
+
+	bundleLayer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return PackWithOptions(directory, packOptions) }, layerOptions...)
 	if err != nil {
 		return name.Digest{}, err
 	}
@@ -95,6 +180,12 @@ func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Opti
 // - dereferencing all symlinks and storing the respective target,
 // - ignoring all files configured in .shpignore
 func Pack(directory string) (io.ReadCloser, error) {
+	return PackWithOptions(directory, PackOptions{})
+}
+
+// PackWithOptions works like Pack, but creates a reproducible tar stream if the options request it.
+// The entries are always written in lexical order.
+func PackWithOptions(directory string, options PackOptions) (io.ReadCloser, error) {
 	var split = func(path string) []string { return strings.Split(path, string(filepath.Separator)) }
 
 	var write = func(w io.Writer, path string) error {
@@ -187,6 +278,8 @@ func Pack(directory string) (io.ReadCloser, error) {
 			return err
 		}
 
+		options.normalize(header)
+
 		switch {
 		case info.Mode().IsDir():
 			return tw.WriteHeader(header)
@@ -214,6 +307,8 @@ func Pack(directory string) (io.ReadCloser, error) {
 				return err
 			}
 
+			options.normalize(header)
+
 			if err := tw.WriteHeader(header); err != nil {
 				return err
 			}
@@ -231,6 +326,13 @@ func Pack(directory string) (io.ReadCloser, error) {
 // Unpack reads a tar stream and writes the content into the local file system
 // with all files and directories.
 func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
+	return UnpackWithLimits(in, targetPath, UnpackLimits{})
+}
+
+// UnpackWithLimits works like Unpack, but fails with a LimitExceededError as soon as the tar stream
+// exceeds one of the limits. The sizes are checked against the tar headers before any content of the
+// file is written.
+func UnpackWithLimits(in io.Reader, targetPath string, limits UnpackLimits) (*UnpackDetails, error) {
 	type chmod struct {
 		name string
 		mode os.FileMode
@@ -247,6 +349,7 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 
 	var chmods []chmod
 	var details = UnpackDetails{}
+	var entries, totalSize int64
 	var tr = tar.NewReader(in)
 	for {
 		header, err := tr.Next()
@@ -273,6 +376,13 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 			return nil, fmt.Errorf("targetPath validation failed, path contains unexpected special elements")
 		}
 
+		if header.Typeflag != tar.TypeXGlobalHeader {
+			entries++
+			if limits.MaxEntries > 0 && entries > limits.MaxEntries {
+				return nil, limitExceeded("the source bundle contains more than %d files and directories", limits.MaxEntries)
+			}
+		}
+
 		switch header.Typeflag {
 		case tar.TypeDir:
 			// Skip the root directory, since it already exists
@@ -287,6 +397,15 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 			chmods = append(chmods, chmod{name: target, mode: fileMode(header)})
 
 		case tar.TypeReg:
+			if limits.MaxFileSize > 0 && header.Size > limits.MaxFileSize {
+				return nil, limitExceeded("the file %s of the source bundle has %d bytes, which exceeds the limit of %d bytes", header.Name, header.Size, limits.MaxFileSize)
+			}
+
+			totalSize += header.Size
+			if limits.MaxSize > 0 && totalSize > limits.MaxSize {
+				return nil, limitExceeded("the files of the source bundle exceed the limit of %d bytes", limits.MaxSize)
+			}
+
 			// Edge case in which that tarball did not have a directory entry
 			dir, _ := filepath.Split(target)
 			if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
@@ -315,6 +434,10 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 				details.MostRecentFileTimestamp = &header.ModTime
 			}
 
+		case tar.TypeXGlobalHeader:
+			// Tarballs created by git archive start with a global header containing the commit, there is nothing to extract
+			continue
+
 		default:
 			return nil, fmt.Errorf("provided tarball contains unsupported file type, only directories and regular files are supported")
 		}
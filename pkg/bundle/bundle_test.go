@@ -5,12 +5,17 @@
 package bundle_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -87,6 +92,145 @@ var _ = Describe("Bundle", func() {
 		})
 	})
 
+	Context("packing reproducibly", func() {
+		// withSourceTree creates a directory with the same content, but with the given modification
+		// time and permissions of the files
+		withSourceTree := func(modTime time.Time, mode os.FileMode, f func(source string)) {
+			withTempDir(func(source string) {
+				Expect(os.Mkdir(filepath.Join(source, "cmd"), 0775)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(source, "cmd", "main.go"), []byte("package main"), mode)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(source, "build.sh"), []byte("#!/bin/sh"), 0755)).To(Succeed())
+
+				for _, path := range []string{filepath.Join(source, "cmd", "main.go"), filepath.Join(source, "build.sh"), filepath.Join(source, "cmd"), source} {
+					Expect(os.Chtimes(path, modTime, modTime)).To(Succeed())
+				}
+
+				f(source)
+			})
+		}
+
+		pack := func(source string, options PackOptions) []byte {
+			r, err := PackWithOptions(source, options)
+			Expect(err).ToNot(HaveOccurred())
+			defer r.Close()
+
+			data, err := io.ReadAll(r)
+			Expect(err).ToNot(HaveOccurred())
+			return data
+		}
+
+		It("should create identical tar streams for identical content", func() {
+			withSourceTree(time.Unix(1234567890, 0), 0644, func(first string) {
+				withSourceTree(time.Now(), 0664, func(second string) {
+					options := PackOptions{Reproducible: true}
+					Expect(pack(first, options)).To(Equal(pack(second, options)))
+					Expect(pack(first, PackOptions{})).ToNot(Equal(pack(second, PackOptions{})))
+				})
+			})
+		})
+
+		It("should normalize the tar headers", func() {
+			withSourceTree(time.Now(), 0664, func(source string) {
+				sourceDateEpoch := time.Unix(1700000000, 0).UTC()
+				tr := tar.NewReader(bytes.NewReader(pack(source, PackOptions{Reproducible: true, SourceDateEpoch: sourceDateEpoch})))
+
+				modes := map[string]int64{}
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(header.ModTime).To(BeTemporally("==", sourceDateEpoch))
+					Expect(header.Uid).To(Equal(0))
+					Expect(header.Gid).To(Equal(0))
+					modes[header.Name] = header.Mode
+				}
+
+				Expect(modes).To(Equal(map[string]int64{".": 0755, "build.sh": 0755, "cmd": 0755, "cmd/main.go": 0644}))
+			})
+		})
+
+		It("should push bundle images with identical digests for identical content", func() {
+			withTempRegistry(func(endpoint string) {
+				ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+				Expect(err).ToNot(HaveOccurred())
+
+				withSourceTree(time.Unix(1234567890, 0), 0644, func(first string) {
+					withSourceTree(time.Now(), 0664, func(second string) {
+						firstDigest, err := PackAndPushWithOptions(ref, first, PackOptions{Reproducible: true})
+						Expect(err).ToNot(HaveOccurred())
+
+						secondDigest, err := PackAndPushWithOptions(ref, second, PackOptions{Reproducible: true})
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(secondDigest).To(Equal(firstDigest))
+					})
+				})
+			})
+		})
+	})
+
+	Context("unpacking with limits", func() {
+		// tarball creates a tar stream with a directory and files of the given sizes
+		tarball := func(sizes ...int) *bytes.Buffer {
+			var buf bytes.Buffer
+			tw := tar.NewWriter(&buf)
+			Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "dir/", Mode: 0755})).To(Succeed())
+
+			for i, size := range sizes {
+				Expect(tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: fmt.Sprintf("dir/file-%d", i), Mode: 0644, Size: int64(size)})).To(Succeed())
+				_, err := tw.Write(bytes.Repeat([]byte("x"), size))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(tw.Close()).To(Succeed())
+			return &buf
+		}
+
+		expectLimitExceeded := func(err error, message string) {
+			var limitExceededError *LimitExceededError
+			Expect(errors.As(err, &limitExceededError)).To(BeTrue())
+			Expect(err).To(MatchError(message))
+		}
+
+		It("should unpack content that is within the limits", func() {
+			withTempDir(func(target string) {
+				_, err := UnpackWithLimits(tarball(100, 200), target, UnpackLimits{MaxSize: 300, MaxFileSize: 200, MaxEntries: 3})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(filepath.Join(target, "dir", "file-0")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "dir", "file-1")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should fail for too many entries", func() {
+			withTempDir(func(target string) {
+				_, err := UnpackWithLimits(tarball(1, 1, 1), target, UnpackLimits{MaxEntries: 3})
+				expectLimitExceeded(err, "the source bundle contains more than 3 files and directories")
+			})
+		})
+
+		It("should fail for a file that is too large without writing it", func() {
+			withTempDir(func(target string) {
+				_, err := UnpackWithLimits(tarball(100, 1024), target, UnpackLimits{MaxFileSize: 1000})
+				expectLimitExceeded(err, "the file dir/file-1 of the source bundle has 1024 bytes, which exceeds the limit of 1000 bytes")
+
+				Expect(filepath.Join(target, "dir", "file-1")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should fail when the files are too large in total", func() {
+			withTempDir(func(target string) {
+				_, err := UnpackWithLimits(tarball(100, 100, 100), target, UnpackLimits{MaxSize: 250})
+				expectLimitExceeded(err, "the files of the source bundle exceed the limit of 250 bytes")
+
+				Expect(filepath.Join(target, "dir", "file-2")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+
 	Context("packing/pushing and pulling/unpacking", func() {
 		It("should pull and unpack an image", func() {
 			withTempRegistry(func(endpoint string) {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 )
 
@@ -77,8 +78,46 @@ const (
 	// environment variable for the Git rewrite setting
 	useGitRewriteRule = "GIT_ENABLE_REWRITE_RULE"
 
+	// environment variable for the Git revision resolution setting
+	useGitResolveRevision = "GIT_RESOLVE_REVISION"
+
+	// environment variables for the CA bundle and proxy settings of the steps that Shipwright adds
+	networkCABundleConfigMapEnvVar = "NETWORK_CA_BUNDLE_CONFIGMAP"
+	networkHTTPProxyEnvVar         = "NETWORK_HTTP_PROXY"
+	networkHTTPSProxyEnvVar        = "NETWORK_HTTPS_PROXY"
+	networkNoProxyEnvVar           = "NETWORK_NO_PROXY"
+
+	// environment variables for the persistent cache of Git objects
+	gitCachePersistentVolumeClaimEnvVar = "GIT_CACHE_PERSISTENT_VOLUME_CLAIM"
+	gitCacheMaxSizeEnvVar               = "GIT_CACHE_MAX_SIZE"
+	gitCacheMaxSizeDefault              = "10Gi"
+
+	// environment variables for the verification of SSH host keys
+	gitSSHStrictHostKeyCheckingEnvVar = "GIT_SSH_STRICT_HOST_KEY_CHECKING"
+	gitSSHKnownHostsConfigMapEnvVar   = "GIT_SSH_KNOWN_HOSTS_CONFIGMAP"
+
+	// environment variables for the limits of the content of source bundles that are unpacked
+	bundleMaxSizeEnvVar      = "BUNDLE_MAX_SIZE"
+	bundleMaxSizeDefault     = "4Gi"
+	bundleMaxFileSizeEnvVar  = "BUNDLE_MAX_FILE_SIZE"
+	bundleMaxFileSizeDefault = "1Gi"
+	bundleMaxEntriesEnvVar   = "BUNDLE_MAX_ENTRIES"
+	bundleMaxEntriesDefault  = 100000
+
 	// environment variable to hold vulnerability count limit
 	VulnerabilityCountLimitEnvVar = "VULNERABILITY_COUNT_LIMIT"
+
+	// environment variables for the trigger webhook receiver
+	triggerWebhookEnabledEnvVar = "TRIGGER_WEBHOOK_ENABLED"
+	triggerWebhookPortEnvVar    = "TRIGGER_WEBHOOK_PORT"
+	triggerWebhookPortDefault   = 8080
+
+	// environment variable for the interval in seconds in which images of Image triggers are checked
+	triggerImagePollIntervalEnvVar  = "TRIGGER_IMAGE_POLL_INTERVAL"
+	triggerImagePollIntervalDefault = 300 * time.Second
+
+	// environment variable for the API hosts besides the repository host that commit statuses are reported to
+	statusReportAllowedAPIHostsEnvVar = "STATUS_REPORT_ALLOWED_API_HOSTS"
 )
 
 var (
@@ -106,7 +145,14 @@ type Config struct {
 	Controllers                      Controllers
 	KubeAPIOptions                   KubeAPIOptions
 	GitRewriteRule                   bool
+	GitResolveRevision               bool
 	VulnerabilityCountLimit          int
+	Triggers                         TriggerOptions
+	StatusReport                     StatusReportOptions
+	Network                          NetworkOptions
+	GitCache                         GitCacheOptions
+	GitSSH                           GitSSHOptions
+	BundleLimits                     BundleLimitsOptions
 }
 
 // PrometheusConfig contains the specific configuration for the
@@ -144,6 +190,46 @@ type KubeAPIOptions struct {
 	Burst int
 }
 
+// TriggerOptions contains configurable options for the Build triggers
+type TriggerOptions struct {
+	WebhookEnabled    bool
+	WebhookPort       int
+	ImagePollInterval time.Duration
+}
+
+// StatusReportOptions contains configurable options for reporting commit statuses
+type StatusReportOptions struct {
+	AllowedAPIHosts []string
+}
+
+// NetworkOptions contains the CA bundle and proxy settings for the steps that Shipwright adds to a build
+type NetworkOptions struct {
+	CABundleConfigMap string
+	HTTPProxy         string
+	HTTPSProxy        string
+	NoProxy           string
+}
+
+// GitCacheOptions contains the settings of the persistent cache of Git objects that is shared across BuildRuns
+type GitCacheOptions struct {
+	PersistentVolumeClaim string
+	MaxSize               resource.Quantity
+}
+
+// GitSSHOptions contains the policy for the host keys of the SSH servers that Git sources are cloned from
+type GitSSHOptions struct {
+	StrictHostKeyChecking bool
+	KnownHostsConfigMap   string
+}
+
+// BundleLimitsOptions contains the default limits of the content of source bundles that are unpacked, a Build
+// can override them for its source
+type BundleLimitsOptions struct {
+	MaxSize     resource.Quantity
+	MaxFileSize resource.Quantity
+	MaxEntries  int64
+}
+
 type Step struct {
 	Args            []string                    `json:"args,omitempty"`
 	Command         []string                    `json:"command,omitempty"`
@@ -162,8 +248,19 @@ func NewDefaultConfig() *Config {
 		RemoteArtifactsContainerImage: remoteArtifactsDefaultImage,
 		TerminationLogPath:            terminationLogPathDefault,
 		GitRewriteRule:                false,
+		GitResolveRevision:            false,
 		VulnerabilityCountLimit:       50,
 
+		GitCache: GitCacheOptions{
+			MaxSize: resource.MustParse(gitCacheMaxSizeDefault),
+		},
+
+		BundleLimits: BundleLimitsOptions{
+			MaxSize:     resource.MustParse(bundleMaxSizeDefault),
+			MaxFileSize: resource.MustParse(bundleMaxFileSizeDefault),
+			MaxEntries:  bundleMaxEntriesDefault,
+		},
+
 		GitContainerTemplate: Step{
 			Image: gitDefaultImage,
 			Command: []string{
@@ -308,6 +405,12 @@ func NewDefaultConfig() *Config {
 			QPS:   0,
 			Burst: 0,
 		},
+
+		Triggers: TriggerOptions{
+			WebhookEnabled:    false,
+			WebhookPort:       triggerWebhookPortDefault,
+			ImagePollInterval: triggerImagePollIntervalDefault,
+		},
 	}
 }
 
@@ -366,6 +469,71 @@ func (c *Config) SetConfigFromEnv() error {
 		c.GitRewriteRule = strings.ToLower(useGitRewriteRule) == "true"
 	}
 
+	if caBundleConfigMap := os.Getenv(networkCABundleConfigMapEnvVar); caBundleConfigMap != "" {
+		c.Network.CABundleConfigMap = caBundleConfigMap
+	}
+
+	if httpProxy := os.Getenv(networkHTTPProxyEnvVar); httpProxy != "" {
+		c.Network.HTTPProxy = httpProxy
+	}
+
+	if httpsProxy := os.Getenv(networkHTTPSProxyEnvVar); httpsProxy != "" {
+		c.Network.HTTPSProxy = httpsProxy
+	}
+
+	if noProxy := os.Getenv(networkNoProxyEnvVar); noProxy != "" {
+		c.Network.NoProxy = noProxy
+	}
+
+	if gitCachePersistentVolumeClaim := os.Getenv(gitCachePersistentVolumeClaimEnvVar); gitCachePersistentVolumeClaim != "" {
+		c.GitCache.PersistentVolumeClaim = gitCachePersistentVolumeClaim
+	}
+
+	if gitCacheMaxSize := os.Getenv(gitCacheMaxSizeEnvVar); gitCacheMaxSize != "" {
+		maxSize, err := resource.ParseQuantity(gitCacheMaxSize)
+		if err != nil {
+			return err
+		}
+		c.GitCache.MaxSize = maxSize
+	}
+
+	if bundleMaxSize := os.Getenv(bundleMaxSizeEnvVar); bundleMaxSize != "" {
+		maxSize, err := resource.ParseQuantity(bundleMaxSize)
+		if err != nil {
+			return err
+		}
+		c.BundleLimits.MaxSize = maxSize
+	}
+
+	if bundleMaxFileSize := os.Getenv(bundleMaxFileSizeEnvVar); bundleMaxFileSize != "" {
+		maxFileSize, err := resource.ParseQuantity(bundleMaxFileSize)
+		if err != nil {
+			return err
+		}
+		c.BundleLimits.MaxFileSize = maxFileSize
+	}
+
+	if bundleMaxEntries := os.Getenv(bundleMaxEntriesEnvVar); bundleMaxEntries != "" {
+		maxEntries, err := strconv.ParseInt(bundleMaxEntries, 10, 64)
+		if err != nil {
+			return err
+		}
+		c.BundleLimits.MaxEntries = maxEntries
+	}
+
+	if strictHostKeyChecking := os.Getenv(gitSSHStrictHostKeyCheckingEnvVar); strictHostKeyChecking != "" {
+		c.GitSSH.StrictHostKeyChecking = strings.ToLower(strictHostKeyChecking) == "true"
+	}
+
+	if knownHostsConfigMap := os.Getenv(gitSSHKnownHostsConfigMapEnvVar); knownHostsConfigMap != "" {
+		c.GitSSH.KnownHostsConfigMap = knownHostsConfigMap
+	}
+
+	// Mark that the BuildRun controller is supposed to resolve Git revisions to commit SHAs
+	if useGitResolveRevision := os.Getenv(useGitResolveRevision); useGitResolveRevision != "" {
+		c.GitResolveRevision = strings.ToLower(useGitResolveRevision) == "true"
+	}
+
 	if bundleContainerTemplate := os.Getenv(bundleContainerTemplateEnvVar); bundleContainerTemplate != "" {
 		c.BundleContainerTemplate = Step{}
 		if err := json.Unmarshal([]byte(bundleContainerTemplate), &c.BundleContainerTemplate); err != nil {
@@ -451,6 +619,26 @@ func (c *Config) SetConfigFromEnv() error {
 		return err
 	}
 
+	// trigger settings
+	if triggerWebhookEnabled := os.Getenv(triggerWebhookEnabledEnvVar); triggerWebhookEnabled != "" {
+		c.Triggers.WebhookEnabled = strings.ToLower(triggerWebhookEnabled) == "true"
+	}
+	if err := updateIntOption(&c.Triggers.WebhookPort, triggerWebhookPortEnvVar); err != nil {
+		return err
+	}
+
+	if pollInterval := os.Getenv(triggerImagePollIntervalEnvVar); pollInterval != "" {
+		i, err := strconv.Atoi(pollInterval)
+		if err != nil {
+			return err
+		}
+		c.Triggers.ImagePollInterval = time.Duration(i) * time.Second
+	}
+
+	if allowedAPIHosts := os.Getenv(statusReportAllowedAPIHostsEnvVar); allowedAPIHosts != "" {
+		c.StatusReport.AllowedAPIHosts = strings.Split(allowedAPIHosts, ",")
+	}
+
 	if terminationLogPath := os.Getenv(terminationLogPathEnvVar); terminationLogPath != "" {
 		c.TerminationLogPath = terminationLogPath
 	}
@@ -101,6 +101,113 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		It("should allow for an override of the trigger webhook configuration", func() {
+			var overrides = map[string]string{
+				"TRIGGER_WEBHOOK_ENABLED": "true",
+				"TRIGGER_WEBHOOK_PORT":    "9090",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.Triggers.WebhookEnabled).To(BeTrue())
+				Expect(config.Triggers.WebhookPort).To(Equal(9090))
+			})
+		})
+
+		It("should allow for an override of the image trigger poll interval", func() {
+			var overrides = map[string]string{
+				"TRIGGER_IMAGE_POLL_INTERVAL": "60",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.Triggers.ImagePollInterval).To(Equal(60 * time.Second))
+			})
+		})
+
+		It("should allow for an override of the allowed commit status API hosts", func() {
+			var overrides = map[string]string{
+				"STATUS_REPORT_ALLOWED_API_HOSTS": "git-api.example.com,git.example.com:8443",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.StatusReport.AllowedAPIHosts).To(Equal([]string{"git-api.example.com", "git.example.com:8443"}))
+			})
+		})
+
+		It("should allow for an override of the network configuration", func() {
+			var overrides = map[string]string{
+				"NETWORK_CA_BUNDLE_CONFIGMAP": "trusted-ca",
+				"NETWORK_HTTP_PROXY":          "http://proxy.example.com:3128",
+				"NETWORK_HTTPS_PROXY":         "http://proxy.example.com:3128",
+				"NETWORK_NO_PROXY":            ".svc,.cluster.local",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.Network).To(Equal(NetworkOptions{
+					CABundleConfigMap: "trusted-ca",
+					HTTPProxy:         "http://proxy.example.com:3128",
+					HTTPSProxy:        "http://proxy.example.com:3128",
+					NoProxy:           ".svc,.cluster.local",
+				}))
+			})
+		})
+
+		It("should allow to configure the Git cache", func() {
+			Expect(NewDefaultConfig().GitCache.PersistentVolumeClaim).To(BeEmpty())
+			Expect(NewDefaultConfig().GitCache.MaxSize.Value()).To(Equal(int64(10 * 1024 * 1024 * 1024)))
+
+			var overrides = map[string]string{
+				"GIT_CACHE_PERSISTENT_VOLUME_CLAIM": "git-cache",
+				"GIT_CACHE_MAX_SIZE":                "500Mi",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.GitCache.PersistentVolumeClaim).To(Equal("git-cache"))
+				Expect(config.GitCache.MaxSize.Value()).To(Equal(int64(500 * 1024 * 1024)))
+			})
+		})
+
+		It("should allow to configure the SSH host key policy", func() {
+			Expect(NewDefaultConfig().GitSSH.StrictHostKeyChecking).To(BeFalse())
+			Expect(NewDefaultConfig().GitSSH.KnownHostsConfigMap).To(BeEmpty())
+
+			var overrides = map[string]string{
+				"GIT_SSH_STRICT_HOST_KEY_CHECKING": "true",
+				"GIT_SSH_KNOWN_HOSTS_CONFIGMAP":    "ssh-known-hosts",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.GitSSH.StrictHostKeyChecking).To(BeTrue())
+				Expect(config.GitSSH.KnownHostsConfigMap).To(Equal("ssh-known-hosts"))
+			})
+		})
+
+		It("should allow to configure the limits of source bundles", func() {
+			Expect(NewDefaultConfig().BundleLimits.MaxSize.Value()).To(Equal(int64(4 * 1024 * 1024 * 1024)))
+			Expect(NewDefaultConfig().BundleLimits.MaxFileSize.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+			Expect(NewDefaultConfig().BundleLimits.MaxEntries).To(Equal(int64(100000)))
+
+			var overrides = map[string]string{
+				"BUNDLE_MAX_SIZE":      "500Mi",
+				"BUNDLE_MAX_FILE_SIZE": "100Mi",
+				"BUNDLE_MAX_ENTRIES":   "1000",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.BundleLimits.MaxSize.Value()).To(Equal(int64(500 * 1024 * 1024)))
+				Expect(config.BundleLimits.MaxFileSize.Value()).To(Equal(int64(100 * 1024 * 1024)))
+				Expect(config.BundleLimits.MaxEntries).To(Equal(int64(1000)))
+			})
+		})
+
+		It("should allow to enable the resolution of Git revisions", func() {
+			Expect(NewDefaultConfig().GitResolveRevision).To(BeFalse())
+
+			var overrides = map[string]string{"GIT_RESOLVE_REVISION": "true"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.GitResolveRevision).To(BeTrue())
+			})
+		})
+
 		It("should allow for an override of the Git container template", func() {
 			var overrides = map[string]string{
 				"GIT_CONTAINER_TEMPLATE": "{\"image\":\"myregistry/custom/git-image\",\"resources\":{\"requests\":{\"cpu\":\"0.5\",\"memory\":\"128Mi\"}}}",
@@ -27,6 +27,11 @@ import (
 	"github.com/shipwright-io/build/pkg/reconciler/buildrunttlcleanup"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
 	"github.com/shipwright-io/build/pkg/reconciler/clusterbuildstrategy"
+	"github.com/shipwright-io/build/pkg/reconciler/commitstatus"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/pipelinetrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
+	"github.com/shipwright-io/build/pkg/trigger/receiver"
 )
 
 // NewManager add all the controllers to the manager and register the required schemes
@@ -99,5 +104,26 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 		return nil, err
 	}
 
+	if err := imagetrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := pipelinetrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := scheduletrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := commitstatus.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	// Add the webhook receiver for Build triggers
+	if err := receiver.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
 	return mgr, nil
 }
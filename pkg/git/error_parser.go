@@ -49,6 +49,12 @@ const (
 	RepositoryNotFound
 	// AuthPrompted is caused when a repo is not found, is private and authentication is insufficient
 	AuthPrompted
+	// SignatureVerificationFailed expresses that the commit or tag is not signed, or not by a trusted key.
+	SignatureVerificationFailed
+	// AuthTokenFailed expresses that no access token could be obtained for a GitHub App or that the access token expired
+	AuthTokenFailed
+	// SSHHostKeyUnverified expresses that the host key of the SSH server is not one of the known hosts, or that no known hosts are available although they are required
+	SSHHostKeyUnverified
 )
 
 type rawToken struct {
@@ -100,6 +106,12 @@ func (class ErrorClass) String() string {
 		return "GitSSHAuthExpected"
 	case AuthUnexpectedHTTP:
 		return "AuthUnexpectedHTTP"
+	case SignatureVerificationFailed:
+		return "GitSignatureVerificationFailed"
+	case AuthTokenFailed:
+		return "GitAuthTokenFailed"
+	case SSHHostKeyUnverified:
+		return "GitSSHHostKeyUnverified"
 	}
 
 	return "GitError"
@@ -126,6 +138,12 @@ func (class ErrorClass) ToMessage() string {
 		return "Basic Auth incomplete: Both username and password need to be configured."
 	case AuthUnexpectedHTTP:
 		return "Refusing to continue with basic authentication (username and password) over insecure HTTP connection"
+	case SignatureVerificationFailed:
+		return "The signature of the commit or tag could not be verified. Make sure that it is signed by one of the trusted keys or identities."
+	case AuthTokenFailed:
+		return "An access token for the source repository could not be obtained. Check the GitHub App or the access token in your secret."
+	case SSHHostKeyUnverified:
+		return "The SSH host key of the Git server could not be verified. Add the host key to the known_hosts of your secret, or ask your administrator to add it to the known hosts of the cluster."
 	}
 
 	return "Git encountered an unknown error."
@@ -211,7 +229,20 @@ func isRepositoryNotFound(raw string) bool {
 }
 
 func isBranchNotFound(raw string) bool {
-	return strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found")
+	return (strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found")) ||
+		strings.Contains(raw, "couldn't find remote ref")
+}
+
+func isSignatureVerificationFailed(raw string) bool {
+	return strings.Contains(raw, "signature verification failed")
+}
+
+func isAuthTokenFailed(raw string) bool {
+	return strings.Contains(raw, "failed to obtain an access token")
+}
+
+func isSSHHostKeyUnverified(raw string) bool {
+	return strings.Contains(raw, "host key verification failed")
 }
 
 func parseErrorMessage(raw string) errorClassToken {
@@ -229,6 +260,12 @@ func parseErrorMessage(raw string) errorClassToken {
 		errorClass = RepositoryNotFound
 	case isBranchNotFound(toCheck):
 		errorClass = RevisionNotFound
+	case isSignatureVerificationFailed(toCheck):
+		errorClass = SignatureVerificationFailed
+	case isAuthTokenFailed(toCheck):
+		errorClass = AuthTokenFailed
+	case isSSHHostKeyUnverified(toCheck):
+		errorClass = SSHHostKeyUnverified
 	}
 
 	return errorClassToken{errorClass, rawToken{
@@ -270,6 +307,12 @@ func classifyTokensWithFatalPrefix(tokens []errorToken) ErrorClass {
 			return RevisionNotFound
 		case AuthInvalidUserOrPass:
 			return AuthInvalidUserOrPass
+		case SignatureVerificationFailed:
+			return SignatureVerificationFailed
+		case AuthTokenFailed:
+			return AuthTokenFailed
+		case SSHHostKeyUnverified:
+			return SSHHostKeyUnverified
 		}
 	}
 
@@ -316,3 +359,26 @@ func extractResultsFromTokens(tokens []errorToken) *ErrorResult {
 func NewErrorResultFromMessage(message string) *ErrorResult {
 	return extractResultsFromTokens(parse(message))
 }
+
+// permanentErrorRegEx matches the messages of Git, curl, and SSH for failed authentication that has no error class,
+// it does not pass when the operation is retried
+var permanentErrorRegEx = regexp.MustCompile(`authentication failed|permission denied|invalid username or password|could not read (username|password)|terminal prompts disabled|returned error: 40[13]`)
+
+// transientErrorRegEx matches the messages of Git and curl for failures of the network or of the Git service that
+// are likely to pass, like DNS failures, reset connections, server errors, rate limits, and aborted transfers
+var transientErrorRegEx = regexp.MustCompile(`could not resolve host|temporary failure in name resolution|connection reset|connection timed out|operation timed out|failed to connect to|returned error: (5[0-9][0-9]|429)|http (5[0-9][0-9]|429)|too many requests|rate limit|early eof|unexpected disconnect|the remote end hung up unexpectedly|curl (18|28|52|55|56)|connection closed by remote host`)
+
+// IsTransientError returns whether the message of a failed Git operation reports an error that is likely to pass when
+// the operation is retried. Errors that have a class, like missing repositories, and authentication failures are not.
+func IsTransientError(message string) bool {
+	if NewErrorResultFromMessage(message).Reason != Unknown {
+		return false
+	}
+
+	message = strings.ToLower(message)
+	if permanentErrorRegEx.MatchString(message) {
+		return false
+	}
+
+	return transientErrorRegEx.MatchString(message)
+}
@@ -35,6 +35,10 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			parsed := parseErrorMessage("Remote branch not found")
 			Expect(parsed.class).To(Equal(RevisionNotFound))
 		})
+		It("should recognize and parse unknown refspec", func() {
+			parsed := parseErrorMessage("couldn't find remote ref refs/pull/8/head")
+			Expect(parsed.class).To(Equal(RevisionNotFound))
+		})
 		It("should recognize and parse invalid auth key", func() {
 			parsed := parseErrorMessage("could not read from remote.")
 			Expect(parsed.class).To(Equal(AuthInvalidKey))
@@ -51,6 +55,18 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			parsed := parseErrorMessage("Repository not found.")
 			Expect(parsed.class).To(Equal(RepositoryNotFound))
 		})
+		It("should recognize a failed signature verification", func() {
+			parsed := parseErrorMessage("signature verification failed for commit HEAD, No principal matched.")
+			Expect(parsed.class).To(Equal(SignatureVerificationFailed))
+		})
+		It("should recognize a failed access token exchange", func() {
+			parsed := parseErrorMessage("failed to obtain an access token: GitHub refused to create a token for installation 1 of GitHub App 2: Not Found")
+			Expect(parsed.class).To(Equal(AuthTokenFailed))
+		})
+		It("should recognize a failed host key verification", func() {
+			parsed := parseErrorMessage("host key verification failed for git@github.com:shipwright-io/build.git, the host key is not one of the known hosts")
+			Expect(parsed.class).To(Equal(SSHHostKeyUnverified))
+		})
 		It("should not be able to specify exact error class for unknown message type", func() {
 			parsed := parseErrorMessage("Something went wrong")
 			Expect(parsed.class).To(Equal(Unknown))
@@ -63,4 +79,22 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			Expect(errorResult.Reason.String()).To(Equal(RepositoryNotFound.String()))
 		})
 	})
+	Context("Detect transient errors", func() {
+		DescribeTable("classifies the error",
+			func(message string, transient bool) {
+				Expect(IsTransientError(message)).To(Equal(transient))
+			},
+			Entry("DNS failure", "fatal: unable to access 'https://github.com/shipwright-io/build/': Could not resolve host: github.com", true),
+			Entry("SSH DNS failure", "ssh: Could not resolve hostname github.com: Temporary failure in name resolution", true),
+			Entry("SSH authentication failure", "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", false),
+			Entry("missing credentials", "fatal: could not read Username for 'https://github.com': terminal prompts disabled", false),
+			Entry("reset connection", "fatal: unable to access 'https://github.com/shipwright-io/build/': Recv failure: Connection reset by peer", true),
+			Entry("server error", "fatal: unable to access 'https://github.com/shipwright-io/build/': The requested URL returned error: 503", true),
+			Entry("rate limit", "fatal: unable to access 'https://github.com/shipwright-io/build/': The requested URL returned error: 429", true),
+			Entry("aborted transfer", "error: RPC failed; curl 56 GnuTLS recv error (-54): Error in the pull function.\nfatal: the remote end hung up unexpectedly\nfatal: early EOF\nfatal: index-pack failed", true),
+			Entry("authentication failure", "remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/shipwright-io/build/'", false),
+			Entry("missing repository", "remote: Repository not found.\nfatal: repository 'https://github.com/shipwright-io/nothing/' not found", false),
+			Entry("client error", "fatal: unable to access 'https://github.com/shipwright-io/build/': The requested URL returned error: 403", false),
+		)
+	})
 })
@@ -8,9 +8,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"regexp"
+	"strings"
 
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 
 	gogitv5 "github.com/go-git/go-git/v5"
@@ -24,6 +29,46 @@ const (
 	gitProtocol   = "ssh"
 )
 
+var (
+	fullCommitShaRegEx = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	commitShaRegEx     = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+// ErrRevisionNotFound is returned when the remote repository has no branch, tag, or default branch
+// the revision can refer to
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// Credentials hold the username and password (or token) to authenticate against a Git server
+// using HTTP basic authentication
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Network holds the CA certificates and proxies that are used to access a remote repository
+type Network struct {
+	// CABundle holds PEM encoded CA certificates that are trusted in addition to the system roots
+	CABundle []byte
+
+	// HTTPProxy is the proxy for HTTP connections
+	HTTPProxy string
+
+	// HTTPSProxy is the proxy for HTTPS connections
+	HTTPSProxy string
+
+	// NoProxy holds the comma-separated hosts, domains, and CIDRs that are accessed without proxy
+	NoProxy string
+}
+
+// ResolvedRevision is the commit a revision of a remote repository points to
+type ResolvedRevision struct {
+	// Ref is the full name of the reference, for example refs/heads/main
+	Ref string
+
+	// CommitSha is the commit the reference points to
+	CommitSha string
+}
+
 // ExtractHostnamePort extracts the hostname and port of the provided Git URL
 func ExtractHostnamePort(url string) (string, int, error) {
 	endpoint, err := transport.NewEndpoint(url)
@@ -62,20 +107,7 @@ func ValidateGitURLExists(ctx context.Context, urlPath string) error {
 
 	switch endpoint.Protocol {
 	case httpsProtocol, httpProtocol:
-		repo := gogitv5.NewRemote(memory.NewStorage(), &config.RemoteConfig{
-			Name: defaultRemote,
-			URLs: []string{urlPath},
-		})
-
-		if _, err := repo.ListContext(ctx, &gogitv5.ListOptions{}); err != nil {
-			// Note: When the urlPath is an valid public path, however, this
-			// path doesn't exist, func will return `authentication required`,
-			// this is maybe misleading. So convert this error message to:
-			// `remote repository unreachable`
-			if errors.Is(err, transport.ErrAuthenticationRequired) {
-				return fmt.Errorf("remote repository unreachable")
-			}
-
+		if _, err := listRemoteReferences(ctx, urlPath, &gogitv5.ListOptions{}); err != nil {
 			return err
 		}
 
@@ -89,3 +121,148 @@ func ValidateGitURLExists(ctx context.Context, urlPath string) error {
 
 	return nil
 }
+
+// ResolveRevision resolves the revision of a remote repository to the commit the branch currently
+// points to, an empty revision resolves the default branch. It returns nil if the revision cannot be
+// resolved up front, which is the case for commit SHAs, tags, or repositories that are not accessed
+// using HTTP(S). Those are resolved when the repository is cloned. An error that wraps
+// ErrRevisionNotFound is returned if the repository has nothing the revision can refer to.
+func ResolveRevision(ctx context.Context, urlPath string, revision string, credentials *Credentials, network *Network) (*ResolvedRevision, error) {
+	endpoint, err := transport.NewEndpoint(urlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Protocol != httpsProtocol && endpoint.Protocol != httpProtocol {
+		return nil, nil
+	}
+
+	if fullCommitShaRegEx.MatchString(revision) {
+		return nil, nil
+	}
+
+	var auth transport.AuthMethod
+	if credentials != nil {
+		auth = &http.BasicAuth{Username: credentials.Username, Password: credentials.Password}
+	}
+
+	listOptions := &gogitv5.ListOptions{Auth: auth}
+	if network != nil {
+		listOptions.CABundle = network.CABundle
+		listOptions.ProxyOptions.URL = network.proxyFor(endpoint)
+	}
+
+	refs, err := listRemoteReferences(ctx, urlPath, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := map[plumbing.ReferenceName]plumbing.Hash{}
+	var head *plumbing.Reference
+	for _, ref := range refs {
+		if ref.Name() == plumbing.HEAD {
+			head = ref
+			continue
+		}
+
+		if ref.Type() == plumbing.HashReference {
+			hashes[ref.Name()] = ref.Hash()
+		}
+	}
+
+	if revision != "" {
+		branch := plumbing.NewBranchReferenceName(revision)
+		if hash, ok := hashes[branch]; ok {
+			return &ResolvedRevision{Ref: branch.String(), CommitSha: hash.String()}, nil
+		}
+
+		// tags and abbreviated commit SHAs are resolved when the repository is cloned
+		if _, ok := hashes[plumbing.NewTagReferenceName(revision)]; ok || commitShaRegEx.MatchString(revision) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("%w: the remote repository has no branch or tag %s", ErrRevisionNotFound, revision)
+	}
+
+	if head == nil {
+		return nil, fmt.Errorf("%w: the remote repository has no default branch", ErrRevisionNotFound)
+	}
+
+	// servers usually advertise the branch HEAD points to, otherwise it is the branch with the same commit
+	if head.Type() == plumbing.SymbolicReference {
+		if hash, ok := hashes[head.Target()]; ok {
+			return &ResolvedRevision{Ref: head.Target().String(), CommitSha: hash.String()}, nil
+		}
+
+		return nil, fmt.Errorf("%w: the default branch %s of the remote repository does not exist", ErrRevisionNotFound, head.Target().Short())
+	}
+
+	for name, hash := range hashes {
+		if name.IsBranch() && hash == head.Hash() {
+			return &ResolvedRevision{Ref: name.String(), CommitSha: hash.String()}, nil
+		}
+	}
+
+	// the source step determines the default branch when cloning
+	return nil, nil
+}
+
+// proxyFor returns the proxy for the endpoint, or an empty string if it is accessed without proxy
+func (n *Network) proxyFor(endpoint *transport.Endpoint) string {
+	for _, noProxy := range strings.Split(n.NoProxy, ",") {
+		noProxy = strings.ToLower(strings.TrimSpace(noProxy))
+		if noProxy == "" {
+			continue
+		}
+
+		if noProxy == "*" {
+			return ""
+		}
+
+		if _, cidr, err := net.ParseCIDR(noProxy); err == nil {
+			if ip := net.ParseIP(endpoint.Host); ip != nil && cidr.Contains(ip) {
+				return ""
+			}
+
+			continue
+		}
+
+		if host, _, err := net.SplitHostPort(noProxy); err == nil {
+			noProxy = host
+		}
+
+		host := strings.ToLower(endpoint.Host)
+		if host == strings.TrimPrefix(noProxy, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(noProxy, ".")) {
+			return ""
+		}
+	}
+
+	if endpoint.Protocol == httpsProtocol {
+		return n.HTTPSProxy
+	}
+
+	return n.HTTPProxy
+}
+
+// listRemoteReferences lists the references of a remote repository like git ls-remote does
+func listRemoteReferences(ctx context.Context, urlPath string, listOptions *gogitv5.ListOptions) ([]*plumbing.Reference, error) {
+	repo := gogitv5.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: defaultRemote,
+		URLs: []string{urlPath},
+	})
+
+	refs, err := repo.ListContext(ctx, listOptions)
+	if err != nil {
+		// Note: When the urlPath is an valid public path, however, this
+		// path doesn't exist, func will return `authentication required`,
+		// this is maybe misleading. So convert this error message to:
+		// `remote repository unreachable`
+		if errors.Is(err, transport.ErrAuthenticationRequired) {
+			return nil, fmt.Errorf("remote repository unreachable")
+		}
+
+		return nil, err
+	}
+
+	return refs, nil
+}
@@ -6,7 +6,15 @@ package git_test
 
 import (
 	"context"
+	"encoding/pem"
 	"errors"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -47,4 +55,127 @@ var _ = Describe("Git", func() {
 		Entry("Check git repository which requires authentication", "git@github.com:shipwright-io/build-fake.git", Equal(errors.New("the source url requires authentication"))),
 		Entry("Check ssh repository which requires authentication", "ssh://github.com/shipwright-io/build-fake", Equal(errors.New("the source url requires authentication"))),
 	)
+
+	Context("resolving revisions", func() {
+		var (
+			repoURL string
+			handler http.Handler
+		)
+
+		gitIn := func(dir string, args ...string) string {
+			out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		BeforeEach(func() {
+			root, err := os.MkdirTemp("", "git-resolve")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, root)
+
+			work := filepath.Join(root, "work")
+			gitIn(root, "init", "--initial-branch", "main", work)
+			gitIn(work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial")
+			gitIn(work, "branch", "feature")
+			gitIn(work, "tag", "v1.0.0")
+			gitIn(root, "clone", "--bare", work, filepath.Join(root, "repo.git"))
+
+			// serve the bare repository using the smart HTTP protocol, credentials are required
+			execPath := gitIn(root, "--exec-path")
+			backend := &cgi.Handler{
+				Path: filepath.Join(execPath, "git-http-backend"),
+				Env:  []string{"GIT_PROJECT_ROOT=" + root, "GIT_HTTP_EXPORT_ALL=1"},
+			}
+
+			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if username, password, ok := r.BasicAuth(); !ok || username != "somebody" || password != "token" {
+					w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				backend.ServeHTTP(w, r)
+			})
+
+			server := httptest.NewServer(handler)
+			DeferCleanup(server.Close)
+
+			repoURL = server.URL + "/repo.git"
+		})
+
+		It("resolves the default branch", func() {
+			resolved, err := git.ResolveRevision(context.TODO(), repoURL, "", &git.Credentials{Username: "somebody", Password: "token"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).ToNot(BeNil())
+			Expect(resolved.Ref).To(Equal("refs/heads/main"))
+			Expect(resolved.CommitSha).To(MatchRegexp("^[0-9a-f]{40}$"))
+		})
+
+		It("resolves a branch", func() {
+			resolved, err := git.ResolveRevision(context.TODO(), repoURL, "feature", &git.Credentials{Username: "somebody", Password: "token"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).ToNot(BeNil())
+			Expect(resolved.Ref).To(Equal("refs/heads/feature"))
+		})
+
+		It("leaves tags and commit SHAs to the clone", func() {
+			resolved, err := git.ResolveRevision(context.TODO(), repoURL, "v1.0.0", &git.Credentials{Username: "somebody", Password: "token"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(BeNil())
+
+			resolved, err = git.ResolveRevision(context.TODO(), repoURL, "0123456789abcdef0123456789abcdef01234567", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(BeNil())
+		})
+
+		It("leaves SSH repositories to the clone", func() {
+			resolved, err := git.ResolveRevision(context.TODO(), "git@github.com:shipwright-io/build.git", "main", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(BeNil())
+		})
+
+		It("fails without credentials", func() {
+			_, err := git.ResolveRevision(context.TODO(), repoURL, "main", nil, nil)
+			Expect(err).To(MatchError("remote repository unreachable"))
+		})
+
+		It("fails for a revision the repository does not have", func() {
+			_, err := git.ResolveRevision(context.TODO(), repoURL, "does-not-exist", &git.Credentials{Username: "somebody", Password: "token"}, nil)
+			Expect(err).To(MatchError(git.ErrRevisionNotFound))
+		})
+
+		It("trusts the CA bundle", func() {
+			server := httptest.NewTLSServer(handler)
+			DeferCleanup(server.Close)
+
+			_, err := git.ResolveRevision(context.TODO(), server.URL+"/repo.git", "main", &git.Credentials{Username: "somebody", Password: "token"}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err).ToNot(MatchError(git.ErrRevisionNotFound))
+
+			caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			resolved, err := git.ResolveRevision(context.TODO(), server.URL+"/repo.git", "main", &git.Credentials{Username: "somebody", Password: "token"}, &git.Network{CABundle: caBundle})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).ToNot(BeNil())
+			Expect(resolved.Ref).To(Equal("refs/heads/main"))
+		})
+
+		It("uses the proxy unless the host is excluded", func() {
+			proxied := false
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				proxied = true
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			DeferCleanup(proxy.Close)
+
+			_, err := git.ResolveRevision(context.TODO(), repoURL, "main", &git.Credentials{Username: "somebody", Password: "token"}, &git.Network{HTTPProxy: proxy.URL})
+			Expect(err).To(HaveOccurred())
+			Expect(proxied).To(BeTrue())
+
+			proxied = false
+			resolved, err := git.ResolveRevision(context.TODO(), repoURL, "main", &git.Credentials{Username: "somebody", Password: "token"}, &git.Network{HTTPProxy: proxy.URL, NoProxy: "example.com, 127.0.0.0/8"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).ToNot(BeNil())
+			Expect(proxied).To(BeFalse())
+		})
+	})
 })
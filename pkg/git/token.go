@@ -0,0 +1,93 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GitHubAPIURL is the URL of the GitHub API, GitHub Enterprise Server uses https://<hostname>/api/v3
+const GitHubAPIURL = "https://api.github.com"
+
+// GitHubAppTokenUsername is the username that is used together with an installation token of a GitHub App
+const GitHubAppTokenUsername = "x-access-token"
+
+// AccessToken is a short-lived token to access Git repositories over HTTPS
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// GitHubAppInstallationToken exchanges a JSON Web Token that is signed with the private key of a GitHub App for a
+// token of an installation of the App, the token grants access to the repositories of the installation for one hour
+func GitHubAppInstallationToken(ctx context.Context, client *http.Client, apiURL string, appID string, installationID string, privateKey []byte) (*AccessToken, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key of GitHub App %s: %w", appID, err)
+	}
+
+	// the issued at time is set in the past to allow for clock drift, GitHub accepts at most ten minutes of validity
+	now := time.Now()
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+	}).SignedString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/app/installations/%s/access_tokens", strings.TrimSuffix(apiURL, "/"), installationID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+signedToken)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+		Message   string    `json:"message"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil && resp.StatusCode == http.StatusCreated {
+		return nil, fmt.Errorf("failed to parse the installation token of GitHub App %s: %w", appID, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		if result.Message == "" {
+			result.Message = http.StatusText(resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("GitHub refused to create a token for installation %s of GitHub App %s: %s", installationID, appID, result.Message)
+	}
+
+	if result.Token == "" {
+		return nil, fmt.Errorf("GitHub returned no token for installation %s of GitHub App %s", installationID, appID)
+	}
+
+	return &AccessToken{Token: result.Token, ExpiresAt: result.ExpiresAt}, nil
+}
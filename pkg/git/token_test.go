@@ -0,0 +1,89 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/git"
+)
+
+var _ = Describe("GitHubAppInstallationToken", func() {
+	var privateKey *rsa.PrivateKey
+	var privateKeyPEM []byte
+	var apiURL string
+
+	BeforeEach(func() {
+		var err error
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		privateKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
+		// a stand-in for the GitHub API that only accepts JSON Web Tokens of App 1234 signed with its key
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/app/installations/5678/access_tokens" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"Not Found"}`)
+				return
+			}
+
+			claims := &jwt.RegisteredClaims{}
+			_, err := jwt.ParseWithClaims(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), claims, func(*jwt.Token) (interface{}, error) {
+				return &privateKey.PublicKey, nil
+			})
+			if err != nil || claims.Issuer != "1234" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"message":"A JSON web token could not be decoded"}`)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"token":"ghs_installationtoken","expires_at":"2030-01-01T00:00:00Z"}`)
+		}))
+		DeferCleanup(server.Close)
+
+		apiURL = server.URL
+	})
+
+	It("mints an installation token", func() {
+		token, err := git.GitHubAppInstallationToken(context.TODO(), http.DefaultClient, apiURL, "1234", "5678", privateKeyPEM)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(token.Token).To(Equal("ghs_installationtoken"))
+		Expect(token.ExpiresAt).To(Equal(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("fails for a JSON Web Token that is not signed with the key of the App", func() {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = git.GitHubAppInstallationToken(context.TODO(), http.DefaultClient, apiURL, "1234", "5678",
+			pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(otherKey)}))
+		Expect(err).To(MatchError("GitHub refused to create a token for installation 5678 of GitHub App 1234: A JSON web token could not be decoded"))
+	})
+
+	It("fails for an unknown installation", func() {
+		_, err := git.GitHubAppInstallationToken(context.TODO(), http.DefaultClient, apiURL, "1234", "9999", privateKeyPEM)
+		Expect(err).To(MatchError("GitHub refused to create a token for installation 9999 of GitHub App 1234: Not Found"))
+	})
+
+	It("fails for an invalid private key", func() {
+		_, err := git.GitHubAppInstallationToken(context.TODO(), http.DefaultClient, apiURL, "1234", "5678", []byte("not a key"))
+		Expect(err).To(MatchError(ContainSubstring("failed to parse the private key of GitHub App 1234")))
+	})
+})
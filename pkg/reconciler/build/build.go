@@ -36,6 +36,8 @@ var validationTypes = [...]string{
 	validate.NodeSelector,
 	validate.Tolerations,
 	validate.SchedulerName,
+	validate.BundleLimits,
+	validate.Network,
 }
 
 // ReconcileBuild reconciles a Build object
@@ -83,7 +85,7 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 
 	// trigger all current validations
 	for _, validationType := range validationTypes {
-		v, err := validate.NewValidation(validationType, b, r.client, r.scheme)
+		v, err := validate.NewValidation(validationType, b, r.client, r.scheme, r.config)
 		if err != nil {
 			// when the validation type is unknown
 			return reconcile.Result{}, err
@@ -92,7 +94,7 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		if err := v.ValidatePath(ctx); err != nil {
 			// We enqueue another reconcile here. This is done only for validation
 			// types where the error can be produced from a failed API call.
-			if validationType == validate.Secrets || validationType == validate.Strategies {
+			if validationType == validate.Secrets || validationType == validate.Strategies || validationType == validate.Network {
 				return reconcile.Result{}, err
 			}
 
@@ -97,6 +97,19 @@ var _ = Describe("Reconcile Build", func() {
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 			})
 
+			It("fails when the secret with the trusted keys does not exist", func() {
+				buildSample.Spec.Source.Git.Verify = &build.GitVerify{Secret: "non-existing-keys"}
+
+				buildSample.Spec.Output.PushSecret = nil
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, build.SpecSourceSecretRefNotFound, "referenced secret non-existing-keys not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+
 			It("succeeds when the secret exists foobar", func() {
 				buildSample.Spec.Source.Git.CloneSecret = ptr.To("existing")
 				buildSample.Spec.Output.PushSecret = nil
@@ -126,6 +139,27 @@ var _ = Describe("Reconcile Build", func() {
 			})
 		})
 
+		Context("when trigger secret is specified", func() {
+			It("fails when the secret does not exist", func() {
+				buildSample.Spec.Output.PushSecret = nil
+				buildSample.Spec.Trigger = &build.Trigger{
+					TriggerSecret: ptr.To("non-existing-webhook-secret"),
+					When: []build.TriggerWhen{{
+						Name:   "push",
+						Type:   build.GitHubWebHookTrigger,
+						GitHub: &build.WhenGitHub{Events: []build.GitHubEventName{build.GitHubPushEvent}},
+					}},
+				}
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, build.SpecTriggerSecretRefNotFound, "referenced secret non-existing-webhook-secret not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when spec output registry secret is specified", func() {
 			It("fails when the secret does not exist", func() {
 
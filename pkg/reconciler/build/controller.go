@@ -7,6 +7,7 @@ package build
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -183,12 +184,23 @@ func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler, maxCo
 				flagReconcile = true
 			}
 
+			if slices.Contains(build.GetNamedSourcesCredentials(), secret.Name) || slices.Contains(build.GetSourceVerificationSecrets(), secret.Name) ||
+				slices.Contains(build.GetSourceCloneSecrets(), secret.Name) {
+				flagReconcile = true
+			}
+
 			if build.Spec.Output.PushSecret != nil {
 				if *build.Spec.Output.PushSecret == secret.Name {
 					flagReconcile = true
 				}
 			}
 
+			if build.Spec.Trigger != nil && build.Spec.Trigger.TriggerSecret != nil {
+				if *build.Spec.Trigger.TriggerSecret == secret.Name {
+					flagReconcile = true
+				}
+			}
+
 			if flagReconcile {
 				reconcileList = append(reconcileList, reconcile.Request{
 					NamespacedName: types.NamespacedName{
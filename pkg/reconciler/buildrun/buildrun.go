@@ -7,6 +7,7 @@ package buildrun
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/ctxlog"
+	shpgit "github.com/shipwright-io/build/pkg/git"
 	buildmetrics "github.com/shipwright-io/build/pkg/metrics"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 	"github.com/shipwright-io/build/pkg/validate"
@@ -251,6 +253,28 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				ctxlog.Info(ctx, fmt.Sprintf("successfully updated BuildRun %s", buildRun.Name), namespace, request.Namespace, name, request.Name)
 			}
 
+			// Resolve the Git revision to a commit once, so that moving the branch does not change what gets built
+			if r.config.GitResolveRevision && buildRun.Status.ResolvedGitRevision == nil {
+				resolved, err := resources.ResolveGitRevision(ctx, r.config, r.client, build, buildRun)
+				switch {
+				case errors.Is(err, shpgit.ErrRevisionNotFound):
+					message := fmt.Sprintf("failed to resolve the revision of the Git source: %v", err)
+					if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, resources.ConditionGitRevisionNotFound); updateErr != nil {
+						return reconcile.Result{}, updateErr
+					}
+
+					return reconcile.Result{}, nil
+
+				case err != nil:
+					// the source step resolves the revision when it clones the repository
+					ctxlog.Info(ctx, "failed to resolve the Git revision, it is resolved when cloning", namespace, request.Namespace, name, request.Name, "error", err.Error())
+
+				case resolved != nil:
+					ctxlog.Info(ctx, "resolved Git revision", namespace, request.Namespace, name, request.Name, "ref", resolved.Ref, "commitSha", resolved.CommitSha)
+					buildRun.Status.ResolvedGitRevision = resolved
+				}
+			}
+
 			// Set the Build spec in the BuildRun status
 			buildRun.Status.BuildSpec = &build.Spec
 			ctxlog.Info(ctx, "updating BuildRun status", namespace, request.Namespace, name, request.Name)
@@ -7,6 +7,8 @@ package buildrun_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"time"
 
@@ -986,6 +988,139 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.CreateCallCount()).To(Equal(1))
 			})
 
+			It("fails the BuildRun when the Git revision does not exist", func() {
+				// a repository that only has the main branch
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+					for _, line := range []string{
+						"# service=git-upload-pack\n",
+						"",
+						"9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a HEAD\x00symref=HEAD:refs/heads/main\n",
+						"9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a refs/heads/main\n",
+						"",
+					} {
+						if line == "" {
+							fmt.Fprint(w, "0000")
+						} else {
+							fmt.Fprintf(w, "%04x%s", len(line)+4, line)
+						}
+					}
+				}))
+				defer server.Close()
+
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+				buildSample.Spec.Source = &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL:      server.URL + "/shipwright-io/sample-go",
+						Revision: ptr.To("does-not-exist"),
+					},
+				}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					Expect(o).To(BeAssignableToTypeOf(&build.BuildRun{}))
+					condition := o.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					Expect(condition.Reason).To(Equal(resources.ConditionGitRevisionNotFound))
+					Expect(condition.Message).To(HavePrefix("failed to resolve the revision of the Git source"))
+					return nil
+				})
+
+				cfg := config.NewDefaultConfig()
+				cfg.GitResolveRevision = true
+				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			It("leaves the Git revision to the clone when the repository cannot be reached", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+				buildSample.Spec.Source = &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL:      "http://127.0.0.1:1/shipwright-io/sample-go",
+						Revision: ptr.To("main"),
+					},
+				}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+					switch object := object.(type) {
+					case *pipelineapi.TaskRun:
+						Expect(object.Spec.TaskSpec.Steps[0].Args).To(ContainElements("--revision", "main"))
+					}
+					return nil
+				})
+
+				cfg := config.NewDefaultConfig()
+				cfg.GitResolveRevision = true
+				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+				Expect(statusWriter.UpdateCallCount()).To(BeNumerically(">", 0))
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				Expect(object.(*build.BuildRun).Status.ResolvedGitRevision).To(BeNil())
+			})
+
+			It("clones the commit the Git revision was already resolved to", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+				buildSample.Spec.Source = &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL:      "http://127.0.0.1:1/shipwright-io/sample-go",
+						Revision: ptr.To("main"),
+					},
+				}
+				buildRunSample.Status.ResolvedGitRevision = &build.ResolvedGitRevision{
+					Revision:  "main",
+					Ref:       "refs/heads/main",
+					CommitSha: "9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a",
+				}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+					switch object := object.(type) {
+					case *pipelineapi.TaskRun:
+						Expect(object.Spec.TaskSpec.Steps[0].Args).To(ContainElements("--revision", "9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a"))
+					}
+					return nil
+				})
+
+				cfg := config.NewDefaultConfig()
+				cfg.GitResolveRevision = true
+				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+			})
+
 			It("succeeds creating a TaskRun from a cluster buildstrategy", func() {
 				// override the Build to use a cluster BuildStrategy
 				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
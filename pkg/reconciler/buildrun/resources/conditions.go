@@ -45,6 +45,7 @@ const (
 	BuildRunNoRefOrSpec                              string = "BuildRunNoRefOrSpec"
 	BuildRunAmbiguousBuild                           string = "BuildRunAmbiguousBuild"
 	BuildRunBuildFieldOverrideForbidden              string = "BuildRunBuildFieldOverrideForbidden"
+	ConditionGitRevisionNotFound                     string = "GitRevisionNotFound"
 )
 
 // UpdateBuildRunUsingTaskRunCondition updates the BuildRun Succeeded Condition
@@ -16,6 +16,7 @@ import (
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -80,6 +81,172 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Source.Git.CommitAuthor).To(Equal("foo bar"))
 		})
 
+		It("should surface the signer of a verified Git source", func() {
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL:    "https://github.com/shipwright-io/sample-go",
+						Verify: &build.GitVerify{Secret: "trusted-keys"},
+					},
+				},
+			}
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-signer",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "shipwright@example.com",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Git.Signer).To(Equal("shipwright@example.com"))
+		})
+
+		It("should surface the fetched ref of a Git source", func() {
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL:     "https://github.com/shipwright-io/sample-go",
+						Refspec: ptr.To("refs/pull/42/head"),
+					},
+				},
+			}
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-fetched-ref",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "refs/pull/42/head",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Git.FetchedRef).To(Equal("refs/pull/42/head"))
+		})
+
+		It("should surface the commit details of a Git source", func() {
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+					},
+				},
+			}
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-committer",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "GitHub",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-committer-date",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "1619426578",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-subject",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "Merge pull request #42 from shipwright-io/feature",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-tags",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "v0.1.0,latest",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-describe",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "v0.1.0",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-remote-url",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "https://github.com/shipwright-io/sample-go",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Git.Committer).To(Equal("GitHub"))
+			Expect(br.Status.Source.Git.CommitterDate).ToNot(BeNil())
+			Expect(br.Status.Source.Git.CommitterDate.Unix()).To(Equal(int64(1619426578)))
+			Expect(br.Status.Source.Git.CommitSubject).To(Equal("Merge pull request #42 from shipwright-io/feature"))
+			Expect(br.Status.Source.Git.Tags).To(Equal([]string{"v0.1.0", "latest"}))
+			Expect(br.Status.Source.Git.Describe).To(Equal("v0.1.0"))
+			Expect(br.Status.Source.Git.RemoteURL).To(Equal("https://github.com/shipwright-io/sample-go"))
+		})
+
+		It("should surface the branch name of a resolved default branch", func() {
+			commitSha := "0e0583421a5e4bf562ffe33f3651e16ba0c78591"
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+					},
+				},
+			}
+			br.Status.ResolvedGitRevision = &build.ResolvedGitRevision{
+				Ref:       "refs/heads/main",
+				CommitSha: commitSha,
+			}
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: commitSha,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Git.CommitSha).To(Equal(commitSha))
+			Expect(br.Status.Source.Git.BranchName).To(Equal("main"))
+		})
+
 		It("should surface the TaskRun results emitting from default(bundle) source step", func() {
 			bundleImageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 			br.Status.BuildSpec = &build.BuildSpec{
@@ -106,6 +273,73 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Source.OciArtifact.Digest).To(Equal(bundleImageDigest))
 		})
 
+		It("should surface the TaskRun results emitting from named source steps keyed by their name", func() {
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+					},
+				},
+				Sources: []build.NamedSource{
+					{
+						Name: "config",
+						Type: build.GitType,
+						Git: &build.Git{
+							URL: "https://github.com/shipwright-io/sample-config",
+						},
+					},
+					{
+						Name: "assets",
+						Type: build.OCIArtifactType,
+						OCIArtifact: &build.OCIArtifact{
+							Image: "ghcr.io/shipwright-io/sample-go/assets:latest",
+						},
+					},
+				},
+			}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-config-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-config-source-timestamp",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "1691650396",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-assets-image-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "sha256:fe1b73cd25ac3f11dec752755e2",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source.Git.CommitSha).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+			Expect(br.Status.Sources).To(HaveLen(2))
+			Expect(br.Status.Sources[0].Name).To(Equal("config"))
+			Expect(br.Status.Sources[0].Git.CommitSha).To(Equal("9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+			Expect(br.Status.Sources[0].Timestamp.Unix()).To(Equal(int64(1691650396)))
+			Expect(br.Status.Sources[1].Name).To(Equal("assets"))
+			Expect(br.Status.Sources[1].OciArtifact.Digest).To(Equal("sha256:fe1b73cd25ac3f11dec752755e2"))
+		})
+
 		It("should surface the TaskRun results emitting from output step with image vulnerabilities", func() {
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 			tr.Status.Results = append(tr.Status.Results,
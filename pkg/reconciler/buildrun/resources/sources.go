@@ -5,15 +5,23 @@
 package resources
 
 import (
+	"context"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
+	shpgit "github.com/shipwright-io/build/pkg/git"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+	"github.com/shipwright-io/build/pkg/util"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
@@ -39,17 +47,144 @@ func isLocalCopyBuildSource(
 	return nil
 }
 
-func appendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec) {
+// gitRevisionOverride returns the Git revision override of the BuildRun, or nil if there is none.
+func gitRevisionOverride(buildRun *buildv1beta1.BuildRun) *string {
+	if buildRun.Spec.Source != nil && buildRun.Spec.Source.Type == buildv1beta1.GitType && buildRun.Spec.Source.Git != nil {
+		return buildRun.Spec.Source.Git.Revision
+	}
+
+	return nil
+}
+
+// ResolveGitRevision resolves the revision of the Git source of the Build to the commit it currently
+// points to, so that the source step clones exactly that commit. It returns nil if the Build has no Git
+// source, or if the revision can only be resolved when cloning, for example for SSH repositories or
+// refspecs. The repository is accessed with the CA bundle and proxies that the source step uses.
+func ResolveGitRevision(ctx context.Context, cfg *config.Config, client client.Client, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun) (*buildv1beta1.ResolvedGitRevision, error) {
+	if isLocalCopyBuildSource(build, buildRun) != nil || build.Spec.Source == nil || build.Spec.Source.Type != buildv1beta1.GitType || build.Spec.Source.Git == nil {
+		return nil, nil
+	}
+
+	// a refspec is fetched by the source step, it is not a branch that can be resolved up front
+	source := build.Spec.Source.Git
+	if source.Refspec != nil && *source.Refspec != "" {
+		return nil, nil
+	}
+
+	var revision string
+	if override := gitRevisionOverride(buildRun); override != nil {
+		revision = *override
+	} else if source.Revision != nil {
+		revision = *source.Revision
+	}
+
+	// only basic authentication can be used to list the references of the repository, with an SSH key the source
+	// step clones over SSH, which includes HTTPS URLs that are rewritten when the Git rewrite rule is enabled
+	var credentials *shpgit.Credentials
+	if source.CloneSecret != nil {
+		secret := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Name: *source.CloneSecret, Namespace: buildRun.Namespace}, secret); err != nil {
+			return nil, err
+		}
+
+		username, hasUsername := secret.Data[corev1.BasicAuthUsernameKey]
+		password, hasPassword := secret.Data[corev1.BasicAuthPasswordKey]
+		if !hasUsername || !hasPassword {
+			return nil, nil
+		}
+
+		credentials = &shpgit.Credentials{Username: string(username), Password: string(password)}
+	}
+
+	network, err := gitNetwork(ctx, cfg, client, build, buildRun.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := shpgit.ResolveRevision(ctx, source.URL, revision, credentials, network)
+	if err != nil || resolved == nil {
+		return nil, err
+	}
+
+	return &buildv1beta1.ResolvedGitRevision{
+		Revision:  revision,
+		Ref:       resolved.Ref,
+		CommitSha: resolved.CommitSha,
+	}, nil
+}
+
+// gitNetwork returns the CA bundle and proxies for accessing the Git repository of the Build. Like for the source step,
+// they come from the ConfigMap of the Build if it defines one, or from the configuration of the cluster. The CA bundle of
+// the controller is trusted as well.
+func gitNetwork(ctx context.Context, cfg *config.Config, client client.Client, build *buildv1beta1.Build, namespace string) (*shpgit.Network, error) {
+	caBundle, err := util.CABundle()
+	if err != nil {
+		return nil, err
+	}
+
+	network := &shpgit.Network{
+		CABundle:   caBundle,
+		HTTPProxy:  cfg.Network.HTTPProxy,
+		HTTPSProxy: cfg.Network.HTTPSProxy,
+		NoProxy:    cfg.Network.NoProxy,
+	}
+
+	configMapName := cfg.Network.CABundleConfigMap
+	if build.Spec.Network != nil {
+		configMapName = build.Spec.Network.ConfigMap
+		network.HTTPProxy, network.HTTPSProxy, network.NoProxy = "", "", ""
+	}
+
+	if configMapName == "" {
+		return network, nil
+	}
+
+	// the ConfigMap and its keys are optional for the steps as well
+	configMap := &corev1.ConfigMap{}
+	if err := client.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: namespace}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return network, nil
+		}
+
+		return nil, err
+	}
+
+	if configMapCABundle, ok := configMap.Data[buildv1beta1.BuildNetworkCABundleKey]; ok {
+		network.CABundle = append(append(network.CABundle, '\n'), configMapCABundle...)
+	}
+
+	if build.Spec.Network != nil {
+		network.HTTPProxy = configMap.Data[buildv1beta1.BuildNetworkHTTPProxyKey]
+		network.HTTPSProxy = configMap.Data[buildv1beta1.BuildNetworkHTTPSProxyKey]
+		network.NoProxy = configMap.Data[buildv1beta1.BuildNetworkNoProxyKey]
+	}
+
+	return network, nil
+}
+
+func appendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec, name string) {
 	taskSpec.Results = append(taskSpec.Results,
 		pipelineapi.TaskResult{
-			Name:        sources.TaskResultName(defaultSourceName, sourceTimestampName),
+			Name:        sources.TaskResultName(name, sourceTimestampName),
 			Description: "The timestamp of the source.",
 		},
 	)
 }
 
+// sourceTimestamp returns the timestamp emitted by the step of the source with the given name, or nil
+func sourceTimestamp(results []pipelineapi.TaskRunResult, name string) *metav1.Time {
+	if value := sources.FindResultValue(results, name, sourceTimestampName); strings.TrimSpace(value) != "" {
+		if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return &metav1.Time{Time: time.Unix(sec, 0)}
+		}
+	}
+
+	return nil
+}
+
 // AmendTaskSpecWithSources adds the necessary steps to either wait for user upload ("LocalCopy"), or
-// alternatively, configures the Task steps to use bundle and "git clone".
+// alternatively, configures the Task steps to use bundle, "git clone", or an archive download. The named sources of the
+// Build are fetched into their target directories afterwards.
 func AmendTaskSpecWithSources(
 	cfg *config.Config,
 	taskSpec *pipelineapi.TaskSpec,
@@ -60,25 +195,66 @@ func AmendTaskSpecWithSources(
 		sources.AppendLocalCopyStep(cfg, taskSpec, localCopy.Timeout)
 	} else if build.Spec.Source != nil {
 
-		// create the step for spec.source, either Git or Bundle
+		// create the step for spec.source, either Git, Bundle, or HTTP
 		switch build.Spec.Source.Type {
 		case buildv1beta1.OCIArtifactType:
 			if build.Spec.Source.OCIArtifact != nil {
-				appendSourceTimestampResult(taskSpec)
-				sources.AppendBundleStep(cfg, taskSpec, build.Spec.Source.OCIArtifact, defaultSourceName)
+				appendSourceTimestampResult(taskSpec, defaultSourceName)
+				sources.AppendBundleStep(cfg, taskSpec, build.Spec.Source.OCIArtifact, defaultSourceName, "")
+			}
+		case buildv1beta1.HTTPType:
+			if build.Spec.Source.HTTP != nil {
+				appendSourceTimestampResult(taskSpec, defaultSourceName)
+				sources.AppendHTTPStep(cfg, taskSpec, build.Spec.Source.HTTP, defaultSourceName, "")
 			}
 		case buildv1beta1.GitType:
 			if build.Spec.Source.Git != nil {
-				appendSourceTimestampResult(taskSpec)
-				sources.AppendGitStep(cfg, taskSpec, *build.Spec.Source.Git, defaultSourceName)
+				git := *build.Spec.Source.Git
+
+				// a BuildRun can override the revision, for example when created by a trigger
+				if revision := gitRevisionOverride(buildRun); revision != nil {
+					git.Revision = revision
+				}
+
+				// the controller already resolved the revision, clone exactly the commit it resolved to
+				if resolved := buildRun.Status.ResolvedGitRevision; resolved != nil {
+					git.Revision = &resolved.CommitSha
+				}
+
+				// a sparse checkout must contain the context directory, otherwise there is nothing to build
+				if contextDir := build.Spec.Source.ContextDir; len(git.SparseCheckout) > 0 && contextDir != nil && *contextDir != "" && !slices.Contains(git.SparseCheckout, *contextDir) {
+					git.SparseCheckout = append(slices.Clone(git.SparseCheckout), *contextDir)
+				}
+
+				appendSourceTimestampResult(taskSpec, defaultSourceName)
+				sources.AppendGitStep(cfg, taskSpec, git, defaultSourceName, "")
 			}
 		}
 	}
+
+	// create the steps for spec.sources, each one fetches into its own sub-directory of the source root
+	for _, source := range build.Spec.Sources {
+		switch {
+		case source.Type == buildv1beta1.OCIArtifactType && source.OCIArtifact != nil:
+			appendSourceTimestampResult(taskSpec, source.Name)
+			sources.AppendBundleStep(cfg, taskSpec, source.OCIArtifact, source.Name, source.GetTargetDirectory())
+
+		case source.Type == buildv1beta1.GitType && source.Git != nil:
+			appendSourceTimestampResult(taskSpec, source.Name)
+			sources.AppendGitStep(cfg, taskSpec, *source.Git, source.Name, source.GetTargetDirectory())
+
+		case source.Type == buildv1beta1.HTTPType && source.HTTP != nil:
+			appendSourceTimestampResult(taskSpec, source.Name)
+			sources.AppendHTTPStep(cfg, taskSpec, source.HTTP, source.Name, source.GetTargetDirectory())
+		}
+	}
 }
 
 func updateBuildRunStatusWithSourceResult(buildrun *buildv1beta1.BuildRun, results []pipelineapi.TaskRunResult) {
 	buildSpec := buildrun.Status.BuildSpec
 
+	updateBuildRunStatusWithNamedSourceResults(buildrun, results)
+
 	if buildSpec.Source == nil {
 		return
 	}
@@ -89,13 +265,47 @@ func updateBuildRunStatusWithSourceResult(buildrun *buildv1beta1.BuildRun, resul
 
 	case buildSpec.Source.Type == buildv1beta1.GitType && buildSpec.Source.Git != nil:
 		sources.AppendGitResult(buildrun, defaultSourceName, results)
+
+		// the source step cloned a commit, the branch name is known from the resolution of the default branch
+		if resolved := buildrun.Status.ResolvedGitRevision; resolved != nil && resolved.Revision == "" && buildrun.Status.Source != nil && buildrun.Status.Source.Git != nil && buildrun.Status.Source.Git.BranchName == "" {
+			buildrun.Status.Source.Git.BranchName = strings.TrimPrefix(resolved.Ref, "refs/heads/")
+		}
+
+	case buildSpec.Source.Type == buildv1beta1.HTTPType && buildSpec.Source.HTTP != nil:
+		sources.AppendHTTPResult(buildrun, defaultSourceName, results)
 	}
 
-	if sourceTimestamp := sources.FindResultValue(results, defaultSourceName, sourceTimestampName); strings.TrimSpace(sourceTimestamp) != "" {
-		if sec, err := strconv.ParseInt(sourceTimestamp, 10, 64); err == nil {
-			if buildrun.Status.Source != nil {
-				buildrun.Status.Source.Timestamp = &metav1.Time{Time: time.Unix(sec, 0)}
-			}
+	if timestamp := sourceTimestamp(results, defaultSourceName); timestamp != nil {
+		if buildrun.Status.Source != nil {
+			buildrun.Status.Source.Timestamp = timestamp
+		}
+	}
+}
+
+// updateBuildRunStatusWithNamedSourceResults sets the results of the named sources, keyed by their name
+func updateBuildRunStatusWithNamedSourceResults(buildrun *buildv1beta1.BuildRun, results []pipelineapi.TaskRunResult) {
+	var namedResults []buildv1beta1.NamedSourceResult
+	for _, source := range buildrun.Status.BuildSpec.Sources {
+		result := buildv1beta1.NamedSourceResult{Name: source.Name}
+
+		switch {
+		case source.Type == buildv1beta1.OCIArtifactType && source.OCIArtifact != nil:
+			result.OciArtifact = sources.BundleSourceResult(source.Name, results)
+
+		case source.Type == buildv1beta1.GitType && source.Git != nil:
+			result.Git = sources.GitSourceResult(source.Name, results)
+
+		case source.Type == buildv1beta1.HTTPType && source.HTTP != nil:
+			result.HTTP = sources.HTTPSourceResult(source.Name, results)
 		}
+
+		if result.OciArtifact == nil && result.Git == nil && result.HTTP == nil {
+			continue
+		}
+
+		result.Timestamp = sourceTimestamp(results, source.Name)
+		namedResults = append(namedResults, result)
 	}
+
+	buildrun.Status.Sources = namedResults
 }
@@ -6,6 +6,7 @@ package sources
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
@@ -15,8 +16,9 @@ import (
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
-// AppendBundleStep appends the bundle step to the TaskSpec
-func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *build.OCIArtifact, name string) {
+// AppendBundleStep appends the bundle step to the TaskSpec, the bundle is extracted into the target
+// directory relative to the source root, or into the source root if it is empty
+func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *build.OCIArtifact, name string, targetDirectory string) {
 	// append the result
 	taskSpec.Results = append(taskSpec.Results,
 		pipelineapi.TaskResult{
@@ -33,9 +35,11 @@ func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *b
 		Command:         cfg.BundleContainerTemplate.Command,
 		Args: []string{
 			"--image", oci.Image,
-			"--target", fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+			"--target", sourceTarget(targetDirectory),
 			"--result-file-image-digest", fmt.Sprintf("$(results.%s-source-%s-image-digest.path)", PrefixParamsResultsVolumes, name),
 			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
+			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", PrefixParamsResultsVolumes),
+			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", PrefixParamsResultsVolumes),
 		},
 		Env:              cfg.BundleContainerTemplate.Env,
 		ComputeResources: cfg.BundleContainerTemplate.Resources,
@@ -62,6 +66,8 @@ func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *b
 		)
 	}
 
+	bundleStep.Args = append(bundleStep.Args, bundleLimitsArgs(cfg, oci.Limits)...)
+
 	// add prune flag in when prune after pull is configured
 	if oci.Prune != nil && *oci.Prune == build.PruneAfterPull {
 		bundleStep.Args = append(bundleStep.Args, "--prune")
@@ -70,16 +76,67 @@ func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *b
 	taskSpec.Steps = append(taskSpec.Steps, bundleStep)
 }
 
+// bundleLimitsArgs returns the arguments for the limits of the unpacked content, the limits of the
+// Build can only lower the ones of the configuration
+func bundleLimitsArgs(cfg *config.Config, limits *build.BundleLimits) []string {
+	maxSize, maxFileSize, maxEntries := cfg.BundleLimits.MaxSize.Value(), cfg.BundleLimits.MaxFileSize.Value(), cfg.BundleLimits.MaxEntries
+
+	if limits != nil {
+		if limits.MaxSize != nil {
+			maxSize = lowerLimit(maxSize, limits.MaxSize.Value())
+		}
+
+		if limits.MaxFileSize != nil {
+			maxFileSize = lowerLimit(maxFileSize, limits.MaxFileSize.Value())
+		}
+
+		if limits.MaxEntries != nil {
+			maxEntries = lowerLimit(maxEntries, *limits.MaxEntries)
+		}
+	}
+
+	return []string{
+		"--max-size", strconv.FormatInt(maxSize, 10),
+		"--max-file-size", strconv.FormatInt(maxFileSize, 10),
+		"--max-entries", strconv.FormatInt(maxEntries, 10),
+	}
+}
+
+// lowerLimit returns the limit of the Build if it lowers the configured one. A configured limit of 0 means
+// that there is no limit, a limit of the Build that is not greater than 0 would disable it and is ignored.
+func lowerLimit(configured int64, limit int64) int64 {
+	switch {
+	case limit <= 0:
+		return configured
+
+	case configured <= 0:
+		return limit
+
+	default:
+		return min(configured, limit)
+	}
+}
+
 // AppendBundleResult append bundle source result to build run
 func AppendBundleResult(buildRun *build.BuildRun, name string, results []pipelineapi.TaskRunResult) {
-	imageDigest := FindResultValue(results, name, "image-digest")
-
-	if strings.TrimSpace(imageDigest) != "" {
+	if bundleResult := BundleSourceResult(name, results); bundleResult != nil {
 		if buildRun.Status.Source == nil {
 			buildRun.Status.Source = &build.SourceResult{}
 		}
-		buildRun.Status.Source.OciArtifact = &build.OciArtifactSourceResult{
-			Digest: imageDigest,
-		}
+		buildRun.Status.Source.OciArtifact = bundleResult
+	}
+}
+
+// BundleSourceResult returns the result of the bundle source with the given name, or nil if its step
+// did not emit any result
+func BundleSourceResult(name string, results []pipelineapi.TaskRunResult) *build.OciArtifactSourceResult {
+	imageDigest := FindResultValue(results, name, "image-digest")
+
+	if strings.TrimSpace(imageDigest) == "" {
+		return nil
+	}
+
+	return &build.OciArtifactSourceResult{
+		Digest: imageDigest,
 	}
 }
@@ -0,0 +1,113 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Bundle", func() {
+
+	cfg := config.NewDefaultConfig()
+
+	Context("when adding an OCI artifact source", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		It("adds a step that unpacks the image with the limits of the configuration", func() {
+			sources.AppendBundleStep(cfg, taskSpec, &buildv1beta1.OCIArtifact{
+				Image: "registry.example.com/org/source:latest",
+			}, "default", "")
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-default"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.BundleContainerTemplate.Image))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--image", "registry.example.com/org/source:latest",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-image-digest", "$(results.shp-source-default-image-digest.path)",
+				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
+				"--result-file-error-message", "$(results.shp-error-message.path)",
+				"--result-file-error-reason", "$(results.shp-error-reason.path)",
+				"--max-size", "4294967296",
+				"--max-file-size", "1073741824",
+				"--max-entries", "100000",
+			}))
+		})
+
+		It("uses the limits of the Build when they are lower than the ones of the configuration", func() {
+			sources.AppendBundleStep(cfg, taskSpec, &buildv1beta1.OCIArtifact{
+				Image: "registry.example.com/org/source:latest",
+				Limits: &buildv1beta1.BundleLimits{
+					MaxSize:    ptr.To(resource.MustParse("100Mi")),
+					MaxEntries: ptr.To[int64](500),
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-size", "104857600"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-file-size", "1073741824"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-entries", "500"))
+		})
+
+		It("does not use limits of the Build that exceed the ones of the configuration", func() {
+			sources.AppendBundleStep(cfg, taskSpec, &buildv1beta1.OCIArtifact{
+				Image: "registry.example.com/org/source:latest",
+				Limits: &buildv1beta1.BundleLimits{
+					MaxSize:     ptr.To(resource.MustParse("8Gi")),
+					MaxFileSize: ptr.To(resource.MustParse("100Mi")),
+					MaxEntries:  ptr.To[int64](250000),
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-size", "4294967296"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-file-size", "104857600"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-entries", "100000"))
+		})
+
+		It("does not use limits of the Build that are not greater than 0", func() {
+			sources.AppendBundleStep(cfg, taskSpec, &buildv1beta1.OCIArtifact{
+				Image: "registry.example.com/org/source:latest",
+				Limits: &buildv1beta1.BundleLimits{
+					MaxSize:     ptr.To(resource.MustParse("0")),
+					MaxFileSize: ptr.To(resource.MustParse("-1Mi")),
+					MaxEntries:  ptr.To[int64](0),
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-size", "4294967296"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-file-size", "1073741824"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-entries", "100000"))
+		})
+
+		It("uses the limits of the Build when the configuration has no limits", func() {
+			unlimited := config.NewDefaultConfig()
+			unlimited.BundleLimits = config.BundleLimitsOptions{}
+
+			sources.AppendBundleStep(unlimited, taskSpec, &buildv1beta1.OCIArtifact{
+				Image: "registry.example.com/org/source:latest",
+				Limits: &buildv1beta1.BundleLimits{
+					MaxSize:    ptr.To(resource.MustParse("100Mi")),
+					MaxEntries: ptr.To[int64](500),
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-size", "104857600"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-file-size", "0"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-entries", "500"))
+		})
+	})
+})
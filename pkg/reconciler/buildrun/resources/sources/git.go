@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
@@ -23,14 +26,33 @@ const (
 	commitSHAResult    = "commit-sha"
 	commitAuthorResult = "commit-author"
 	branchName         = "branch-name"
+	commitSignerResult = "commit-signer"
+	fetchedRefResult   = "fetched-ref"
+
+	committerResult     = "committer"
+	committerDateResult = "committer-date"
+	commitSubjectResult = "commit-subject"
+	tagsResult          = "tags"
+	describeResult      = "describe"
+	remoteURLResult     = "remote-url"
+)
+
+const (
+	// knownHostsVolumeName is the name of the volume with the known hosts of the cluster
+	knownHostsVolumeName = "shp-ssh-known-hosts"
+
+	// knownHostsKey is the key of the ConfigMap with the known hosts of the cluster
+	knownHostsKey = "known_hosts"
 )
 
-// AppendGitStep appends the Git step and results and volume if needed to the TaskSpec
+// AppendGitStep appends the Git step and results and volume if needed to the TaskSpec, the source is
+// cloned into the target directory relative to the source root, or into the source root if it is empty
 func AppendGitStep(
 	cfg *config.Config,
 	taskSpec *pipelineapi.TaskSpec,
 	source buildv1beta1.Git,
 	name string,
+	targetDirectory string,
 ) {
 	// append the result
 	taskSpec.Results = append(taskSpec.Results,
@@ -46,6 +68,30 @@ func AppendGitStep(
 			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, branchName),
 			Description: "The name of the branch used of the cloned source.",
 		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, committerResult),
+			Description: "The committer of the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, committerDateResult),
+			Description: "The committer date of the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, commitSubjectResult),
+			Description: "The subject of the message of the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, tagsResult),
+			Description: "The tags that point at the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, describeResult),
+			Description: "The output of git describe for the last commit of the cloned source.",
+		},
+		pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, remoteURLResult),
+			Description: "The URL of the cloned source without credentials.",
+		},
 	)
 
 	// initialize the step from the template and the build-specific arguments
@@ -56,10 +102,16 @@ func AppendGitStep(
 		Command:         cfg.GitContainerTemplate.Command,
 		Args: []string{
 			"--url", source.URL,
-			"--target", fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+			"--target", sourceTarget(targetDirectory),
 			"--result-file-commit-sha", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitSHAResult),
 			"--result-file-commit-author", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitAuthorResult),
 			"--result-file-branch-name", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, branchName),
+			"--result-file-committer", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, committerResult),
+			"--result-file-committer-date", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, committerDateResult),
+			"--result-file-commit-subject", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitSubjectResult),
+			"--result-file-tags", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, tagsResult),
+			"--result-file-describe", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, describeResult),
+			"--result-file-remote-url", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, remoteURLResult),
 			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", PrefixParamsResultsVolumes),
 			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", PrefixParamsResultsVolumes),
 			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
@@ -80,6 +132,20 @@ func AppendGitStep(
 		)
 	}
 
+	// Check if a refspec is defined, the fetched ref is reported as result
+	if source.Refspec != nil && *source.Refspec != "" {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, fetchedRefResult),
+			Description: "The reference that was fetched for the cloned source.",
+		})
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--refspec", *source.Refspec,
+			"--result-file-fetched-ref", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, fetchedRefResult),
+		)
+	}
+
 	// Check if shallow clone is requested
 	if source.Depth != nil && *source.Depth >= 0 {
 		gitStep.Args = append(
@@ -89,11 +155,94 @@ func AppendGitStep(
 		)
 	}
 
+	// Check if a partial clone is requested
+	if source.Filter != nil && *source.Filter != "" {
+		gitStep.Args = append(gitStep.Args, "--filter", *source.Filter)
+	}
+
+	// Check if a sparse checkout is requested
+	for _, path := range source.SparseCheckout {
+		gitStep.Args = append(gitStep.Args, "--sparse-checkout", path)
+	}
+
+	// Check which submodules should be initialized
+	if source.Submodules != nil {
+		if source.Submodules.Enabled != nil && !*source.Submodules.Enabled {
+			gitStep.Args = append(gitStep.Args, "--submodules=false")
+		}
+
+		for _, path := range source.Submodules.Paths {
+			gitStep.Args = append(gitStep.Args, "--submodule-path", path)
+		}
+
+		if source.Submodules.RecursionDepth != nil && *source.Submodules.RecursionDepth > 0 {
+			gitStep.Args = append(gitStep.Args, "--submodule-depth", strconv.Itoa(*source.Submodules.RecursionDepth))
+		}
+	}
+
+	// Check which Git LFS files should be fetched
+	if source.LFS != nil {
+		if source.LFS.Enabled != nil && !*source.LFS.Enabled {
+			gitStep.Args = append(gitStep.Args, "--lfs=false")
+		}
+
+		for _, pattern := range source.LFS.Include {
+			gitStep.Args = append(gitStep.Args, "--lfs-include", pattern)
+		}
+
+		for _, pattern := range source.LFS.Exclude {
+			gitStep.Args = append(gitStep.Args, "--lfs-exclude", pattern)
+		}
+	}
+
+	// Check if the signature should be verified, the signer is reported as result
+	if source.Verify != nil {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", PrefixParamsResultsVolumes, name, commitSignerResult),
+			Description: "The signer of the verified commit or tag of the cloned source.",
+		})
+
+		AppendSecretVolume(taskSpec, source.Verify.Secret)
+
+		verifySecretMountPath := fmt.Sprintf("/workspace/%s-source-verify-secret", PrefixParamsResultsVolumes)
+
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(source.Verify.Secret),
+			MountPath: verifySecretMountPath,
+			ReadOnly:  true,
+		})
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--verify-secret-path", verifySecretMountPath,
+			"--result-file-commit-signer", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitSignerResult),
+		)
+	}
+
 	// If configure, use Git URL rewrite flag
 	if cfg.GitRewriteRule {
 		gitStep.Args = append(gitStep.Args, "--git-url-rewrite")
 	}
 
+	// Check which SSH host keys are trusted, the known hosts of the cluster are merged with the ones of the clone secret
+	if cfg.GitSSH.KnownHostsConfigMap != "" {
+		appendKnownHostsVolume(taskSpec, cfg.GitSSH.KnownHostsConfigMap)
+
+		knownHostsMountPath := fmt.Sprintf("/workspace/%s-ssh-known-hosts", PrefixParamsResultsVolumes)
+
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      knownHostsVolumeName,
+			MountPath: knownHostsMountPath,
+			ReadOnly:  true,
+		})
+
+		gitStep.Args = append(gitStep.Args, "--known-hosts-file", knownHostsMountPath+"/"+knownHostsKey)
+	}
+
+	if cfg.GitSSH.StrictHostKeyChecking {
+		gitStep.Args = append(gitStep.Args, "--strict-host-key-checking")
+	}
+
 	if source.CloneSecret != nil {
 		// ensure the value is there
 		AppendSecretVolume(taskSpec, *source.CloneSecret)
@@ -115,24 +264,166 @@ func AppendGitStep(
 		)
 	}
 
+	// the secrets for other URLs are mounted next to the clone secret
+	for i, cloneSecret := range source.CloneSecrets {
+		AppendSecretVolume(taskSpec, cloneSecret.Secret)
+
+		secretMountPath := fmt.Sprintf("/workspace/%s-source-secret-%d", PrefixParamsResultsVolumes, i)
+
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(cloneSecret.Secret),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		gitStep.Args = append(gitStep.Args, "--url-secret-path", fmt.Sprintf("%s=%s", cloneSecret.URLPrefix, secretMountPath))
+	}
+
+	// Check if the persistent cache of Git objects should be used
+	if claimName := gitCacheClaimName(cfg, source); claimName != "" {
+		volumeName := appendGitCacheVolume(taskSpec, claimName)
+
+		cacheMountPath := fmt.Sprintf("/workspace/%s-git-cache", PrefixParamsResultsVolumes)
+
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: cacheMountPath,
+		})
+
+		gitStep.Args = append(gitStep.Args, "--cache-dir", cacheMountPath)
+
+		if maxSize := cfg.GitCache.MaxSize.Value(); maxSize > 0 {
+			gitStep.Args = append(gitStep.Args, "--cache-max-size", strconv.FormatInt(maxSize, 10))
+		}
+	}
+
 	// append the git step
 	taskSpec.Steps = append(taskSpec.Steps, gitStep)
 }
 
+// gitCacheClaimName returns the PersistentVolumeClaim that holds the Git cache of the source, which is the
+// one of the source or the one configured for the cluster, or an empty string if the cache is not used
+func gitCacheClaimName(cfg *config.Config, source buildv1beta1.Git) string {
+	if source.Cache == nil {
+		return cfg.GitCache.PersistentVolumeClaim
+	}
+
+	if source.Cache.Enabled != nil && !*source.Cache.Enabled {
+		return ""
+	}
+
+	if source.Cache.PersistentVolumeClaim != nil && *source.Cache.PersistentVolumeClaim != "" {
+		return *source.Cache.PersistentVolumeClaim
+	}
+
+	return cfg.GitCache.PersistentVolumeClaim
+}
+
+// appendGitCacheVolume appends the volume for the PersistentVolumeClaim of the Git cache unless it exists
+// already because another source uses the same cache, and returns its name
+func appendGitCacheVolume(taskSpec *pipelineapi.TaskSpec, claimName string) string {
+	volumeName := SanitizeVolumeNameForSecretName("git-cache-" + claimName)
+
+	for _, volume := range taskSpec.Volumes {
+		if volume.VolumeSource.PersistentVolumeClaim != nil && volume.Name == volumeName {
+			return volumeName
+		}
+	}
+
+	taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+
+	return volumeName
+}
+
+// appendKnownHostsVolume appends the volume for the ConfigMap with the known hosts of the cluster unless another
+// source added it already, the ConfigMap is optional so that namespaces without it can still clone
+func appendKnownHostsVolume(taskSpec *pipelineapi.TaskSpec, configMapName string) {
+	for _, volume := range taskSpec.Volumes {
+		if volume.Name == knownHostsVolumeName {
+			return
+		}
+	}
+
+	taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+		Name: knownHostsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+				Items: []corev1.KeyToPath{{
+					Key:  knownHostsKey,
+					Path: knownHostsKey,
+				}},
+				Optional: ptr.To(true),
+			},
+		},
+	})
+}
+
 // AppendGitResult append git source result to build run
 func AppendGitResult(buildRun *buildv1beta1.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	if gitResult := GitSourceResult(name, results); gitResult != nil {
+		if buildRun.Status.Source == nil {
+			buildRun.Status.Source = &build.SourceResult{}
+		}
+		buildRun.Status.Source.Git = gitResult
+	}
+}
+
+// GitSourceResult returns the result of the Git source with the given name, or nil if its step did
+// not emit any result
+func GitSourceResult(name string, results []pipelineapi.TaskRunResult) *build.GitSourceResult {
 	commitAuthor := FindResultValue(results, name, commitAuthorResult)
 	commitSha := FindResultValue(results, name, commitSHAResult)
 	branchName := FindResultValue(results, name, branchName)
+	commitSigner := FindResultValue(results, name, commitSignerResult)
+	fetchedRef := FindResultValue(results, name, fetchedRefResult)
+	committer := FindResultValue(results, name, committerResult)
+	commitSubject := FindResultValue(results, name, commitSubjectResult)
+	describe := FindResultValue(results, name, describeResult)
+	remoteURL := FindResultValue(results, name, remoteURLResult)
 
-	if strings.TrimSpace(commitAuthor) != "" || strings.TrimSpace(commitSha) != "" || strings.TrimSpace(branchName) != "" {
-		if buildRun.Status.Source == nil {
-			buildRun.Status.Source = &build.SourceResult{}
-		}
-		buildRun.Status.Source.Git = &v1beta1.GitSourceResult{
-			CommitAuthor: commitAuthor,
-			CommitSha:    commitSha,
-			BranchName:   branchName,
+	if strings.TrimSpace(commitAuthor) == "" && strings.TrimSpace(commitSha) == "" && strings.TrimSpace(branchName) == "" {
+		return nil
+	}
+
+	return &v1beta1.GitSourceResult{
+		CommitAuthor:  commitAuthor,
+		CommitSha:     commitSha,
+		BranchName:    branchName,
+		Signer:        commitSigner,
+		FetchedRef:    fetchedRef,
+		Committer:     committer,
+		CommitterDate: committerDate(results, name),
+		CommitSubject: commitSubject,
+		Tags:          tags(results, name),
+		Describe:      describe,
+		RemoteURL:     remoteURL,
+	}
+}
+
+// committerDate parses the committer date result, which holds the seconds since the epoch
+func committerDate(results []pipelineapi.TaskRunResult, name string) *metav1.Time {
+	if value := strings.TrimSpace(FindResultValue(results, name, committerDateResult)); value != "" {
+		if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return &metav1.Time{Time: time.Unix(sec, 0)}
 		}
 	}
+
+	return nil
+}
+
+// tags splits the comma-separated tags result
+func tags(results []pipelineapi.TaskRunResult, name string) []string {
+	if value := strings.TrimSpace(FindResultValue(results, name, tagsResult)); value != "" {
+		return strings.Split(value, ",")
+	}
+
+	return nil
 }
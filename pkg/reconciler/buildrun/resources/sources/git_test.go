@@ -13,6 +13,7 @@ import (
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -30,14 +31,20 @@ var _ = Describe("Git", func() {
 		JustBeforeEach(func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL: "https://github.com/shipwright-io/build",
-			}, "default")
+			}, "default", "")
 		})
 
-		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+		It("adds results for the commit sha, commit author, branch name and further commit details", func() {
+			Expect(len(taskSpec.Results)).To(Equal(9))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-committer"))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-committer-date"))
+			Expect(taskSpec.Results[5].Name).To(Equal("shp-source-default-commit-subject"))
+			Expect(taskSpec.Results[6].Name).To(Equal("shp-source-default-tags"))
+			Expect(taskSpec.Results[7].Name).To(Equal("shp-source-default-describe"))
+			Expect(taskSpec.Results[8].Name).To(Equal("shp-source-default-remote-url"))
 		})
 
 		It("adds a step", func() {
@@ -50,6 +57,12 @@ var _ = Describe("Git", func() {
 				"--result-file-commit-sha", "$(results.shp-source-default-commit-sha.path)",
 				"--result-file-commit-author", "$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name", "$(results.shp-source-default-branch-name.path)",
+				"--result-file-committer", "$(results.shp-source-default-committer.path)",
+				"--result-file-committer-date", "$(results.shp-source-default-committer-date.path)",
+				"--result-file-commit-subject", "$(results.shp-source-default-commit-subject.path)",
+				"--result-file-tags", "$(results.shp-source-default-tags.path)",
+				"--result-file-describe", "$(results.shp-source-default-describe.path)",
+				"--result-file-remote-url", "$(results.shp-source-default-remote-url.path)",
 				"--result-file-error-message", "$(results.shp-error-message.path)",
 				"--result-file-error-reason", "$(results.shp-error-reason.path)",
 				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
@@ -68,14 +81,20 @@ var _ = Describe("Git", func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL:         "git@github.com:shipwright-io/build.git",
 				CloneSecret: ptr.To("a.secret"),
-			}, "default")
+			}, "default", "")
 		})
 
-		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+		It("adds results for the commit sha, commit author, branch name and further commit details", func() {
+			Expect(len(taskSpec.Results)).To(Equal(9))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-committer"))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-committer-date"))
+			Expect(taskSpec.Results[5].Name).To(Equal("shp-source-default-commit-subject"))
+			Expect(taskSpec.Results[6].Name).To(Equal("shp-source-default-tags"))
+			Expect(taskSpec.Results[7].Name).To(Equal("shp-source-default-describe"))
+			Expect(taskSpec.Results[8].Name).To(Equal("shp-source-default-remote-url"))
 		})
 
 		It("adds a volume for the secret", func() {
@@ -95,6 +114,12 @@ var _ = Describe("Git", func() {
 				"--result-file-commit-sha", "$(results.shp-source-default-commit-sha.path)",
 				"--result-file-commit-author", "$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name", "$(results.shp-source-default-branch-name.path)",
+				"--result-file-committer", "$(results.shp-source-default-committer.path)",
+				"--result-file-committer-date", "$(results.shp-source-default-committer-date.path)",
+				"--result-file-commit-subject", "$(results.shp-source-default-commit-subject.path)",
+				"--result-file-tags", "$(results.shp-source-default-tags.path)",
+				"--result-file-describe", "$(results.shp-source-default-describe.path)",
+				"--result-file-remote-url", "$(results.shp-source-default-remote-url.path)",
 				"--result-file-error-message", "$(results.shp-error-message.path)",
 				"--result-file-error-reason", "$(results.shp-error-reason.path)",
 				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
@@ -107,6 +132,234 @@ var _ = Describe("Git", func() {
 		})
 	})
 
+	Context("when adding a Git source with a sparse checkout and a partial clone", func() {
+		It("adds the --filter and --sparse-checkout arguments", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL:            "https://github.com/shipwright-io/build",
+				Filter:         ptr.To("blob:none"),
+				SparseCheckout: []string{"cmd/git", "pkg/git"},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--filter", "blob:none"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--sparse-checkout", "cmd/git", "--sparse-checkout", "pkg/git"))
+		})
+	})
+
+	Context("when adding a Git source with submodule and Git LFS settings", func() {
+		It("adds the submodule and LFS arguments", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build",
+				Submodules: &buildv1beta1.GitSubmodules{
+					Paths:          []string{"third_party/lib"},
+					RecursionDepth: ptr.To(1),
+				},
+				LFS: &buildv1beta1.GitLFS{
+					Include: []string{"assets/**"},
+					Exclude: []string{"test/fixtures/**"},
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--submodule-path", "third_party/lib", "--submodule-depth", "1"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--lfs-include", "assets/**", "--lfs-exclude", "test/fixtures/**"))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--submodules=false"))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--lfs=false"))
+		})
+
+		It("disables submodules and Git LFS", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL:        "https://github.com/shipwright-io/build",
+				Submodules: &buildv1beta1.GitSubmodules{Enabled: ptr.To(false)},
+				LFS:        &buildv1beta1.GitLFS{Enabled: ptr.To(false)},
+			}, "default", "")
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--submodules=false", "--lfs=false"))
+		})
+	})
+
+	Context("when adding a Git source with signature verification", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL:    "https://github.com/shipwright-io/build",
+				Verify: &buildv1beta1.GitVerify{Secret: "trusted-keys"},
+			}, "default", "")
+		})
+
+		It("adds a result for the signer", func() {
+			Expect(taskSpec.Results).To(HaveLen(10))
+			Expect(taskSpec.Results[9].Name).To(Equal("shp-source-default-commit-signer"))
+		})
+
+		It("mounts the secret with the trusted keys", func() {
+			Expect(taskSpec.Volumes).To(HaveLen(1))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("trusted-keys"))
+			Expect(taskSpec.Steps[0].VolumeMounts).To(HaveLen(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-source-verify-secret"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--verify-secret-path", "/workspace/shp-source-verify-secret"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--result-file-commit-signer", "$(results.shp-source-default-commit-signer.path)"))
+		})
+	})
+
+	Context("when the cluster has a SSH host key policy", func() {
+		var taskSpec *pipelineapi.TaskSpec
+		var sshCfg *config.Config
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sshCfg = config.NewDefaultConfig()
+			sshCfg.GitSSH.StrictHostKeyChecking = true
+			sshCfg.GitSSH.KnownHostsConfigMap = "ssh-known-hosts"
+
+			git := buildv1beta1.Git{URL: "git@github.com:shipwright-io/build.git"}
+			sources.AppendGitStep(sshCfg, taskSpec, git, "default", "")
+			sources.AppendGitStep(sshCfg, taskSpec, git, "config", "config")
+		})
+
+		It("mounts the known hosts of the cluster once", func() {
+			Expect(taskSpec.Volumes).To(HaveLen(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-ssh-known-hosts"))
+			Expect(taskSpec.Volumes[0].ConfigMap.Name).To(Equal("ssh-known-hosts"))
+			Expect(*taskSpec.Volumes[0].ConfigMap.Optional).To(BeTrue())
+
+			for _, step := range taskSpec.Steps {
+				Expect(step.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "shp-ssh-known-hosts",
+					MountPath: "/workspace/shp-ssh-known-hosts",
+					ReadOnly:  true,
+				}))
+				Expect(step.Args).To(ContainElements("--known-hosts-file", "/workspace/shp-ssh-known-hosts/known_hosts"))
+			}
+		})
+
+		It("requires known hosts", func() {
+			Expect(taskSpec.Steps[0].Args).To(ContainElement("--strict-host-key-checking"))
+		})
+	})
+
+	Context("when adding a Git source with secrets for other URLs", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL:         "https://github.com/shipwright-io/build",
+				CloneSecret: ptr.To("github-credentials"),
+				CloneSecrets: []buildv1beta1.GitCloneSecret{
+					{URLPrefix: "https://gitlab.com/", Secret: "gitlab-credentials"},
+					{URLPrefix: "https://github.com/other-org/", Secret: "github-credentials"},
+				},
+			}, "default", "")
+		})
+
+		It("mounts each secret once", func() {
+			Expect(taskSpec.Volumes).To(HaveLen(2))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("github-credentials"))
+			Expect(taskSpec.Volumes[1].VolumeSource.Secret.SecretName).To(Equal("gitlab-credentials"))
+			Expect(taskSpec.Steps[0].VolumeMounts).To(HaveLen(3))
+			Expect(taskSpec.Steps[0].VolumeMounts[1].MountPath).To(Equal("/workspace/shp-source-secret-0"))
+			Expect(taskSpec.Steps[0].VolumeMounts[2].MountPath).To(Equal("/workspace/shp-source-secret-1"))
+		})
+
+		It("passes the URL prefixes and the paths of their secrets", func() {
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--url-secret-path", "https://gitlab.com/=/workspace/shp-source-secret-0",
+				"--url-secret-path", "https://github.com/other-org/=/workspace/shp-source-secret-1",
+			))
+		})
+	})
+
+	Context("when adding a Git source with a refspec", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL:     "https://github.com/shipwright-io/build",
+				Refspec: ptr.To("refs/pull/42/head"),
+			}, "default", "")
+		})
+
+		It("adds a result for the fetched ref", func() {
+			Expect(taskSpec.Results).To(HaveLen(10))
+			Expect(taskSpec.Results[9].Name).To(Equal("shp-source-default-fetched-ref"))
+		})
+
+		It("passes the refspec to the step", func() {
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--refspec", "refs/pull/42/head"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--result-file-fetched-ref", "$(results.shp-source-default-fetched-ref.path)"))
+		})
+	})
+
+	Context("when adding a Git source with a cache", func() {
+		var taskSpec *pipelineapi.TaskSpec
+		var cacheCfg *config.Config
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			cacheCfg = config.NewDefaultConfig()
+			cacheCfg.GitCache.PersistentVolumeClaim = "cluster-git-cache"
+		})
+
+		It("mounts the cache of the cluster", func() {
+			sources.AppendGitStep(cacheCfg, taskSpec, buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build",
+			}, "default", "")
+
+			Expect(taskSpec.Volumes).To(HaveLen(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-git-cache-cluster-git-cache"))
+			Expect(taskSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("cluster-git-cache"))
+
+			Expect(taskSpec.Steps[0].VolumeMounts).To(HaveLen(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].Name).To(Equal("shp-git-cache-cluster-git-cache"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-git-cache"))
+
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--cache-dir", "/workspace/shp-git-cache"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--cache-max-size", "10737418240"))
+		})
+
+		It("mounts the cache of the source and shares the volume between sources", func() {
+			git := buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build",
+				Cache: &buildv1beta1.GitCache{
+					PersistentVolumeClaim: ptr.To("build-git-cache"),
+				},
+			}
+
+			sources.AppendGitStep(cacheCfg, taskSpec, git, "default", "")
+			sources.AppendGitStep(cacheCfg, taskSpec, git, "config", "config")
+
+			Expect(taskSpec.Volumes).To(HaveLen(1))
+			Expect(taskSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("build-git-cache"))
+			Expect(taskSpec.Steps[1].VolumeMounts[0].Name).To(Equal(taskSpec.Volumes[0].Name))
+		})
+
+		It("does not use the cache if the source disables it", func() {
+			sources.AppendGitStep(cacheCfg, taskSpec, buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build",
+				Cache: &buildv1beta1.GitCache{
+					Enabled: ptr.To(false),
+				},
+			}, "default", "")
+
+			Expect(taskSpec.Volumes).To(BeEmpty())
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--cache-dir"))
+		})
+
+		It("does not use the cache if none is configured", func() {
+			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build",
+			}, "default", "")
+
+			Expect(taskSpec.Volumes).To(BeEmpty())
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--cache-dir"))
+		})
+	})
+
 	Context("when adding a Git source with a depth parameter", func() {
 		var taskSpec *pipelineapi.TaskSpec
 
@@ -119,7 +372,7 @@ var _ = Describe("Git", func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL:   "https://github.com/shipwright-io/build",
 				Depth: ptr.To(depth),
-			}, "default")
+			}, "default", "")
 
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			// Check specific arguments for --depth 0
@@ -134,7 +387,7 @@ var _ = Describe("Git", func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL:   "https://github.com/shipwright-io/build",
 				Depth: ptr.To(depth),
-			}, "default")
+			}, "default", "")
 
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			Expect(taskSpec.Steps[0].Args).To(ContainElements("--depth", "1"))
@@ -145,7 +398,7 @@ var _ = Describe("Git", func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL:   "https://github.com/shipwright-io/build",
 				Depth: ptr.To(depth),
-			}, "default")
+			}, "default", "")
 
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			Expect(taskSpec.Steps[0].Args).To(ContainElements("--depth", "5"))
@@ -154,7 +407,7 @@ var _ = Describe("Git", func() {
 		It("does not add --depth argument when source.Depth is nil (not specified)", func() {
 			sources.AppendGitStep(cfg, taskSpec, buildv1beta1.Git{
 				URL: "https://github.com/shipwright-io/build",
-			}, "default")
+			}, "default", "")
 
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			Expect(taskSpec.Steps[0].Args).NotTo(ContainElement("--depth"))
@@ -168,7 +421,7 @@ var _ = Describe("Git", func() {
 				Depth:       ptr.To(depth),
 				Revision:    ptr.To(revision),
 				CloneSecret: ptr.To("a.secret"),
-			}, "default")
+			}, "default", "")
 
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			Expect(taskSpec.Steps[0].Args).To(ContainElements(
@@ -187,7 +440,7 @@ var _ = Describe("Git", func() {
 				Depth:       ptr.To(depth),
 				Revision:    ptr.To(revision),
 				CloneSecret: ptr.To("another.secret"),
-			}, "default")
+			}, "default", "")
 		
 			Expect(len(taskSpec.Steps)).To(Equal(1))
 			Expect(taskSpec.Steps[0].Args).To(ContainElements(
@@ -0,0 +1,83 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"fmt"
+	"strings"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const archiveDigestResult = "archive-digest"
+
+// AppendHTTPStep appends the step that downloads and extracts an archive to the TaskSpec, the archive
+// is extracted into the target directory relative to the source root, or into the source root if it is empty
+func AppendHTTPStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, source *build.HTTP, name string, targetDirectory string) {
+	// append the result
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, archiveDigestResult),
+			Description: "The digest of the downloaded archive.",
+		},
+	)
+
+	// initialize the step from the template and the build-specific arguments, the bundle
+	// command downloads and extracts archives using the same rules as for bundle images
+	httpStep := pipelineapi.Step{
+		Name:            fmt.Sprintf("source-%s", name),
+		Image:           cfg.BundleContainerTemplate.Image,
+		ImagePullPolicy: cfg.BundleContainerTemplate.ImagePullPolicy,
+		Command:         cfg.BundleContainerTemplate.Command,
+		Args: []string{
+			"--url", source.URL,
+			"--target", sourceTarget(targetDirectory),
+			"--result-file-archive-digest", fmt.Sprintf("$(results.%s.path)", TaskResultName(name, archiveDigestResult)),
+			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
+			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", PrefixParamsResultsVolumes),
+			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", PrefixParamsResultsVolumes),
+		},
+		Env:              cfg.BundleContainerTemplate.Env,
+		ComputeResources: cfg.BundleContainerTemplate.Resources,
+		SecurityContext:  cfg.BundleContainerTemplate.SecurityContext,
+		WorkingDir:       cfg.BundleContainerTemplate.WorkingDir,
+	}
+
+	// archives are downloaded from anywhere, therefore the limits of the configuration apply to them as well
+	httpStep.Args = append(httpStep.Args, bundleLimitsArgs(cfg, nil)...)
+
+	if source.Checksum != nil {
+		httpStep.Args = append(httpStep.Args, "--checksum", *source.Checksum)
+	}
+
+	taskSpec.Steps = append(taskSpec.Steps, httpStep)
+}
+
+// AppendHTTPResult append HTTP source result to build run
+func AppendHTTPResult(buildRun *build.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	if httpResult := HTTPSourceResult(name, results); httpResult != nil {
+		if buildRun.Status.Source == nil {
+			buildRun.Status.Source = &build.SourceResult{}
+		}
+		buildRun.Status.Source.HTTP = httpResult
+	}
+}
+
+// HTTPSourceResult returns the result of the HTTP source with the given name, or nil if its step did
+// not emit any result
+func HTTPSourceResult(name string, results []pipelineapi.TaskRunResult) *build.HTTPSourceResult {
+	archiveDigest := FindResultValue(results, name, archiveDigestResult)
+
+	if strings.TrimSpace(archiveDigest) == "" {
+		return nil
+	}
+
+	return &build.HTTPSourceResult{
+		Digest: archiveDigest,
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("HTTP", func() {
+
+	cfg := config.NewDefaultConfig()
+
+	Context("when adding a HTTP source", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		It("adds a result for the archive digest", func() {
+			sources.AppendHTTPStep(cfg, taskSpec, &buildv1beta1.HTTP{
+				URL: "https://example.com/releases/app-1.0.0.tar.gz",
+			}, "default", "")
+
+			Expect(len(taskSpec.Results)).To(Equal(1))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-archive-digest"))
+		})
+
+		It("adds a step that uses the bundle image", func() {
+			sources.AppendHTTPStep(cfg, taskSpec, &buildv1beta1.HTTP{
+				URL: "https://example.com/releases/app-1.0.0.tar.gz",
+			}, "default", "")
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-default"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.BundleContainerTemplate.Image))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--url", "https://example.com/releases/app-1.0.0.tar.gz",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-archive-digest", "$(results.shp-source-default-archive-digest.path)",
+				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
+				"--result-file-error-message", "$(results.shp-error-message.path)",
+				"--result-file-error-reason", "$(results.shp-error-reason.path)",
+				"--max-size", "4294967296",
+				"--max-file-size", "1073741824",
+				"--max-entries", "100000",
+			}))
+		})
+
+		It("adds the checksum argument", func() {
+			sources.AppendHTTPStep(cfg, taskSpec, &buildv1beta1.HTTP{
+				URL:      "https://example.com/releases/app-1.0.0.zip",
+				Checksum: ptr.To("sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+			}, "vendor", "third_party/vendor")
+
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-vendor"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--target", "$(params.shp-source-root)/third_party/vendor"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--checksum", "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"))
+		})
+	})
+
+	Context("when reading the HTTP source result", func() {
+		It("returns the archive digest", func() {
+			result := sources.HTTPSourceResult("default", []pipelineapi.TaskRunResult{{
+				Name:  "shp-source-default-archive-digest",
+				Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "sha256:2cf24dba"},
+			}})
+
+			Expect(result).ToNot(BeNil())
+			Expect(result.Digest).To(Equal("sha256:2cf24dba"))
+		})
+
+		It("returns nil without result", func() {
+			Expect(sources.HTTPSourceResult("default", nil)).To(BeNil())
+		})
+	})
+})
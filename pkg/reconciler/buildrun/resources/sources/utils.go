@@ -6,6 +6,7 @@ package sources
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
@@ -69,6 +70,17 @@ func SanitizeVolumeNameForSecretName(secretName string) string {
 	return sanitizedName
 }
 
+// sourceTarget returns the directory that a source step fetches the source into, which is the source
+// root, or the given sub-directory of it
+func sourceTarget(targetDirectory string) string {
+	sourceRoot := fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot)
+	if targetDirectory == "" {
+		return sourceRoot
+	}
+
+	return path.Join(sourceRoot, targetDirectory)
+}
+
 func TaskResultName(sourceName, resultName string) string {
 	return fmt.Sprintf("%s-source-%s-%s",
 		PrefixParamsResultsVolumes,
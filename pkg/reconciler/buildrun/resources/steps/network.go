@@ -0,0 +1,145 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps
+
+import (
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/util"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	// VolumeNameCABundle is used as a volume name for the ConfigMap volume with the CA bundle
+	VolumeNameCABundle = "shp-ca-bundle"
+
+	// caBundleMountPath is the directory where the CA bundle is mounted into the steps
+	caBundleMountPath = "/workspace/shp-ca-bundle"
+)
+
+// proxyEnvVars maps the keys of the network ConfigMap to the environment variables that configure the proxies,
+// the lowercase variants are set as well because not all tools honor the uppercase ones
+var proxyEnvVars = []struct {
+	key   string
+	names []string
+}{
+	{key: buildapi.BuildNetworkHTTPProxyKey, names: []string{"HTTP_PROXY", "http_proxy"}},
+	{key: buildapi.BuildNetworkHTTPSProxyKey, names: []string{"HTTPS_PROXY", "https_proxy"}},
+	{key: buildapi.BuildNetworkNoProxyKey, names: []string{"NO_PROXY", "no_proxy"}},
+}
+
+// UpdateNetworkConfiguration configures the CA bundle and the proxies for the steps that Shipwright adds to the build. The settings
+// come from the ConfigMap of the Build if it defines one, or from the configuration of the cluster. Build strategy steps are not changed.
+func UpdateNetworkConfiguration(taskSpec *pipelineapi.TaskSpec, buildStrategySteps []buildapi.Step, networkOptions config.NetworkOptions, buildNetwork *buildapi.BuildNetwork) {
+	caBundleConfigMap := networkOptions.CABundleConfigMap
+	if buildNetwork != nil {
+		caBundleConfigMap = buildNetwork.ConfigMap
+	}
+
+	var env []corev1.EnvVar
+	for _, proxyEnvVar := range proxyEnvVars {
+		for _, name := range proxyEnvVar.names {
+			switch {
+			case buildNetwork != nil:
+				env = append(env, corev1.EnvVar{
+					Name: name,
+					ValueFrom: &corev1.EnvVarSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: buildNetwork.ConfigMap},
+							Key:                  proxyEnvVar.key,
+							Optional:             ptr.To(true),
+						},
+					},
+				})
+
+			case clusterProxyValue(networkOptions, proxyEnvVar.key) != "":
+				env = append(env, corev1.EnvVar{Name: name, Value: clusterProxyValue(networkOptions, proxyEnvVar.key)})
+			}
+		}
+	}
+
+	if caBundleConfigMap == "" && len(env) == 0 {
+		return
+	}
+
+	if caBundleConfigMap != "" {
+		env = append(env, corev1.EnvVar{Name: util.CABundleFileEnvVar, Value: caBundleMountPath + "/" + buildapi.BuildNetworkCABundleKey})
+	}
+
+	buildStrategyStepNames := make([]string, len(buildStrategySteps))
+	for i, buildStrategyStep := range buildStrategySteps {
+		buildStrategyStepNames[i] = buildStrategyStep.Name
+	}
+
+	volumeAdded := false
+
+	for i := range taskSpec.Steps {
+		if slices.Contains(buildStrategyStepNames, taskSpec.Steps[i].Name) {
+			continue
+		}
+
+		// environment variables that are already defined for the step take precedence
+		for _, envVar := range env {
+			if !hasEnvVar(taskSpec.Steps[i].Env, envVar.Name) {
+				taskSpec.Steps[i].Env = append(taskSpec.Steps[i].Env, envVar)
+			}
+		}
+
+		if caBundleConfigMap == "" {
+			continue
+		}
+
+		if !volumeAdded {
+			taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+				Name: VolumeNameCABundle,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: caBundleConfigMap},
+						Items: []corev1.KeyToPath{{
+							Key:  buildapi.BuildNetworkCABundleKey,
+							Path: buildapi.BuildNetworkCABundleKey,
+						}},
+						DefaultMode: ptr.To[int32](0444),
+						Optional:    ptr.To(true),
+					},
+				},
+			})
+
+			volumeAdded = true
+		}
+
+		taskSpec.Steps[i].VolumeMounts = append(taskSpec.Steps[i].VolumeMounts, corev1.VolumeMount{
+			Name:      VolumeNameCABundle,
+			MountPath: caBundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+}
+
+func clusterProxyValue(networkOptions config.NetworkOptions, key string) string {
+	switch key {
+	case buildapi.BuildNetworkHTTPProxyKey:
+		return networkOptions.HTTPProxy
+	case buildapi.BuildNetworkHTTPSProxyKey:
+		return networkOptions.HTTPSProxy
+	case buildapi.BuildNetworkNoProxyKey:
+		return networkOptions.NoProxy
+	default:
+		return ""
+	}
+}
+
+func hasEnvVar(env []corev1.EnvVar, name string) bool {
+	for _, envVar := range env {
+		if envVar.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
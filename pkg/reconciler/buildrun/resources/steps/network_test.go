@@ -0,0 +1,152 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps_test
+
+import (
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateNetworkConfiguration", func() {
+
+	var buildStrategySteps []buildapi.Step
+	var taskRunSpec *pipelineapi.TaskSpec
+	var networkOptions config.NetworkOptions
+	var buildNetwork *buildapi.BuildNetwork
+
+	BeforeEach(func() {
+		buildStrategySteps = []buildapi.Step{{
+			Name: "build",
+		}}
+
+		taskRunSpec = &pipelineapi.TaskSpec{
+			Steps: []pipelineapi.Step{{
+				Name: "source-default",
+			}, {
+				Name: "build",
+			}, {
+				Name: "image-processing",
+				Env: []corev1.EnvVar{{
+					Name:  "NO_PROXY",
+					Value: "registry.local",
+				}},
+			}},
+		}
+
+		networkOptions = config.NetworkOptions{}
+		buildNetwork = nil
+	})
+
+	JustBeforeEach(func() {
+		steps.UpdateNetworkConfiguration(taskRunSpec, buildStrategySteps, networkOptions, buildNetwork)
+	})
+
+	Context("without network configuration", func() {
+
+		It("does not change the steps", func() {
+			Expect(taskRunSpec.Volumes).To(BeEmpty())
+			Expect(taskRunSpec.Steps[0].Env).To(BeEmpty())
+			Expect(taskRunSpec.Steps[0].VolumeMounts).To(BeEmpty())
+			Expect(taskRunSpec.Steps[2].Env).To(HaveLen(1))
+		})
+	})
+
+	Context("with the network configuration of the cluster", func() {
+
+		BeforeEach(func() {
+			networkOptions = config.NetworkOptions{
+				CABundleConfigMap: "cluster-ca-bundle",
+				HTTPSProxy:        "http://proxy.local:3128",
+				NoProxy:           ".svc,.cluster.local",
+			}
+		})
+
+		It("mounts the CA bundle into the Shipwright-injected steps", func() {
+			Expect(taskRunSpec.Volumes).To(HaveLen(1))
+			Expect(taskRunSpec.Volumes[0].Name).To(Equal(steps.VolumeNameCABundle))
+			Expect(taskRunSpec.Volumes[0].ConfigMap).ToNot(BeNil())
+			Expect(taskRunSpec.Volumes[0].ConfigMap.Name).To(Equal("cluster-ca-bundle"))
+			Expect(*taskRunSpec.Volumes[0].ConfigMap.Optional).To(BeTrue())
+
+			for _, i := range []int{0, 2} {
+				Expect(taskRunSpec.Steps[i].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      steps.VolumeNameCABundle,
+					MountPath: "/workspace/shp-ca-bundle",
+					ReadOnly:  true,
+				}))
+				Expect(taskRunSpec.Steps[i].Env).To(ContainElement(corev1.EnvVar{
+					Name:  "SHP_CA_BUNDLE_FILE",
+					Value: "/workspace/shp-ca-bundle/ca-bundle.crt",
+				}))
+			}
+		})
+
+		It("sets the proxy environment variables that are configured", func() {
+			Expect(taskRunSpec.Steps[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.local:3128"},
+				corev1.EnvVar{Name: "https_proxy", Value: "http://proxy.local:3128"},
+				corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local"},
+				corev1.EnvVar{Name: "no_proxy", Value: ".svc,.cluster.local"},
+			))
+
+			for _, envVar := range taskRunSpec.Steps[0].Env {
+				Expect(envVar.Name).ToNot(Equal("HTTP_PROXY"))
+			}
+		})
+
+		It("keeps the environment variables that a step already defines", func() {
+			Expect(taskRunSpec.Steps[2].Env).To(ContainElement(corev1.EnvVar{Name: "NO_PROXY", Value: "registry.local"}))
+			Expect(taskRunSpec.Steps[2].Env).ToNot(ContainElement(corev1.EnvVar{Name: "NO_PROXY", Value: ".svc,.cluster.local"}))
+		})
+
+		It("does not change the build strategy steps", func() {
+			Expect(taskRunSpec.Steps[1].Env).To(BeEmpty())
+			Expect(taskRunSpec.Steps[1].VolumeMounts).To(BeEmpty())
+		})
+	})
+
+	Context("with the network configuration of the Build", func() {
+
+		BeforeEach(func() {
+			networkOptions = config.NetworkOptions{
+				CABundleConfigMap: "cluster-ca-bundle",
+				HTTPProxy:         "http://proxy.local:3128",
+			}
+
+			buildNetwork = &buildapi.BuildNetwork{
+				ConfigMap: "build-network",
+			}
+		})
+
+		It("mounts the CA bundle of the Build's ConfigMap", func() {
+			Expect(taskRunSpec.Volumes).To(HaveLen(1))
+			Expect(taskRunSpec.Volumes[0].ConfigMap.Name).To(Equal("build-network"))
+		})
+
+		It("reads the proxies from the Build's ConfigMap", func() {
+			Expect(taskRunSpec.Steps[0].Env).ToNot(ContainElement(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.local:3128"}))
+
+			var httpProxy *corev1.EnvVar
+			for i := range taskRunSpec.Steps[0].Env {
+				if taskRunSpec.Steps[0].Env[i].Name == "HTTP_PROXY" {
+					httpProxy = &taskRunSpec.Steps[0].Env[i]
+				}
+			}
+
+			Expect(httpProxy).ToNot(BeNil())
+			Expect(httpProxy.ValueFrom).ToNot(BeNil())
+			Expect(httpProxy.ValueFrom.ConfigMapKeyRef.Name).To(Equal("build-network"))
+			Expect(httpProxy.ValueFrom.ConfigMapKeyRef.Key).To(Equal("httpProxy"))
+			Expect(*httpProxy.ValueFrom.ConfigMapKeyRef.Optional).To(BeTrue())
+		})
+	})
+})
@@ -363,6 +363,9 @@ func GenerateTaskRun(
 		return nil, err
 	}
 
+	// Configure the CA bundle and the proxies for the Shipwright-injected steps, this includes the image-processing step
+	steps.UpdateNetworkConfiguration(expectedTaskRun.Spec.TaskSpec, strategy.GetBuildSteps(), cfg.Network, build.Spec.Network)
+
 	return expectedTaskRun, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
@@ -82,6 +83,12 @@ var _ = Describe("GenerateTaskrun", func() {
 					"--result-file-commit-sha", "$(results.shp-source-default-commit-sha.path)",
 					"--result-file-commit-author", "$(results.shp-source-default-commit-author.path)",
 					"--result-file-branch-name", "$(results.shp-source-default-branch-name.path)",
+					"--result-file-committer", "$(results.shp-source-default-committer.path)",
+					"--result-file-committer-date", "$(results.shp-source-default-committer-date.path)",
+					"--result-file-commit-subject", "$(results.shp-source-default-commit-subject.path)",
+					"--result-file-tags", "$(results.shp-source-default-tags.path)",
+					"--result-file-describe", "$(results.shp-source-default-describe.path)",
+					"--result-file-remote-url", "$(results.shp-source-default-remote-url.path)",
 					"--result-file-error-message", "$(results.shp-error-message.path)",
 					"--result-file-error-reason", "$(results.shp-error-reason.path)",
 					"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
@@ -97,6 +104,93 @@ var _ = Describe("GenerateTaskrun", func() {
 				Expect(got.Results).To(utils.ContainNamedElement("shp-source-default-commit-sha"))
 			})
 
+			Context("when the buildrun overrides the Git revision", func() {
+				BeforeEach(func() {
+					buildRun.Spec.Source = &buildv1beta1.BuildRunSource{
+						Type: buildv1beta1.GitType,
+						Git: &buildv1beta1.BuildRunGit{
+							Revision: ptr.To("0a8a2e2b5e8b3f2a9d6f1c9e8b7a6d5c4b3a2f1e"),
+						},
+					}
+				})
+
+				It("should clone the revision of the buildrun", func() {
+					Expect(got.Steps[0].Args).To(ContainElements("--revision", "0a8a2e2b5e8b3f2a9d6f1c9e8b7a6d5c4b3a2f1e"))
+				})
+			})
+
+			Context("when the controller resolved the Git revision", func() {
+				BeforeEach(func() {
+					build.Spec.Source.Git.Revision = ptr.To("main")
+					buildRun.Status.ResolvedGitRevision = &buildv1beta1.ResolvedGitRevision{
+						Revision:  "main",
+						Ref:       "refs/heads/main",
+						CommitSha: "9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a",
+					}
+				})
+
+				It("should clone the resolved commit instead of the branch", func() {
+					Expect(got.Steps[0].Args).To(ContainElements("--revision", "9c5e2b4a1f3d6e8b7a0c1d2e3f4a5b6c7d8e9f0a"))
+					Expect(got.Steps[0].Args).ToNot(ContainElement("main"))
+				})
+			})
+
+			Context("when the build uses a sparse checkout", func() {
+				BeforeEach(func() {
+					build.Spec.Source.ContextDir = ptr.To("services/api")
+					build.Spec.Source.Git.SparseCheckout = []string{"libs/common"}
+					build.Spec.Source.Git.Filter = ptr.To("blob:none")
+				})
+
+				It("should check out the declared paths and the context directory", func() {
+					Expect(got.Steps[0].Args).To(ContainElements("--filter", "blob:none"))
+					Expect(got.Steps[0].Args).To(ContainElements("--sparse-checkout", "libs/common"))
+					Expect(got.Steps[0].Args).To(ContainElements("--sparse-checkout", "services/api"))
+					Expect(build.Spec.Source.Git.SparseCheckout).To(Equal([]string{"libs/common"}))
+				})
+			})
+
+			Context("when the build defines named sources", func() {
+				BeforeEach(func() {
+					build.Spec.Sources = []buildv1beta1.NamedSource{
+						{
+							Name: "config",
+							Type: buildv1beta1.GitType,
+							Git: &buildv1beta1.Git{
+								URL:      "https://github.com/shipwright-io/sample-config",
+								Revision: ptr.To("main"),
+							},
+						},
+						{
+							Name:            "assets",
+							Type:            buildv1beta1.OCIArtifactType,
+							TargetDirectory: ptr.To("static/assets"),
+							OCIArtifact: &buildv1beta1.OCIArtifact{
+								Image: "ghcr.io/shipwright-io/sample-go/assets:latest",
+							},
+						},
+					}
+				})
+
+				It("should fetch every named source into its target directory after the default source", func() {
+					Expect(got.Steps[0].Name).To(Equal("source-default"))
+					Expect(got.Steps[1].Name).To(Equal("source-config"))
+					Expect(got.Steps[1].Args).To(ContainElements("--url", "https://github.com/shipwright-io/sample-config"))
+					Expect(got.Steps[1].Args).To(ContainElements("--target", "$(params.shp-source-root)/config"))
+					Expect(got.Steps[1].Args).To(ContainElements("--revision", "main"))
+					Expect(got.Steps[2].Name).To(Equal("source-assets"))
+					Expect(got.Steps[2].Args).To(ContainElements("--image", "ghcr.io/shipwright-io/sample-go/assets:latest"))
+					Expect(got.Steps[2].Args).To(ContainElements("--target", "$(params.shp-source-root)/static/assets"))
+				})
+
+				It("should contain the results of every named source", func() {
+					Expect(got.Results).To(utils.ContainNamedElement("shp-source-config-commit-sha"))
+					Expect(got.Results).To(utils.ContainNamedElement("shp-source-config-source-timestamp"))
+					Expect(got.Results).To(utils.ContainNamedElement("shp-source-assets-image-digest"))
+					Expect(got.Results).To(utils.ContainNamedElement("shp-source-assets-source-timestamp"))
+				})
+			})
+
 			It("should ensure IMAGE is replaced by builder image when needed.", func() {
 				Expect(got.Steps[1].Image).To(Equal("quay.io/containers/buildah:v1.40.0"))
 			})
@@ -0,0 +1,220 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/scm"
+)
+
+// maxDescriptionLength is the maximum length of a commit status description accepted by GitHub
+const maxDescriptionLength = 140
+
+// commitSHA matches full commit SHAs, BuildRuns created by Git triggers are pinned to one
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// ReconcileCommitStatus reconciles BuildRuns of Builds with a Git source that reports its status.
+// It reports the BuildRun as pending commit status while it runs, and as success or failure once
+// it completed.
+type ReconcileCommitStatus struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+
+	// newStatusClient creates the client for the API of the Git service
+	newStatusClient scm.StatusClientFactory
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return NewReconcilerWithStatusClientFactory(c, mgr, scm.NewStatusClient)
+}
+
+// NewReconcilerWithStatusClientFactory returns a new reconcile.Reconciler that uses the factory to
+// create the clients for the APIs of the Git services
+func NewReconcilerWithStatusClientFactory(c *config.Config, mgr manager.Manager, newStatusClient scm.StatusClientFactory) reconcile.Reconciler {
+	return &ReconcileCommitStatus{
+		config:          c,
+		client:          mgr.GetClient(),
+		newStatusClient: newStatusClient,
+	}
+}
+
+// Reconcile reports the status of a BuildRun as commit status to the Git service, unless it was already reported
+func (r *ReconcileCommitStatus) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling commit status", namespace, request.Namespace, name, request.Name)
+
+	buildRun := &build.BuildRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, buildRun); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling commit status. buildrun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if !reportsStatus(buildRun) {
+		return reconcile.Result{}, nil
+	}
+
+	git := buildRun.Status.BuildSpec.Source.Git
+	statusReport := git.StatusReport
+
+	status := commitStatus(buildRun, statusReport)
+	if buildRun.Annotations[build.AnnotationCommitStatus] == string(status.State) {
+		ctxlog.Debug(ctx, "finish reconciling commit status. status was already reported", namespace, request.Namespace, name, request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	sha := commitSHAOf(buildRun)
+	if sha == "" {
+		// the commit of a branch or tag is only known once the source step reported it, the BuildRun is reconciled again then
+		ctxlog.Debug(ctx, "finish reconciling commit status. commit is not known yet", namespace, request.Namespace, name, request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	repository, err := scm.ParseRepository(git.URL)
+	if err != nil {
+		ctxlog.Info(ctx, "cannot report commit status", namespace, request.Namespace, name, request.Name, "error", err)
+		return reconcile.Result{}, nil
+	}
+
+	token, err := r.token(ctx, buildRun.Namespace, statusReport.Secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Info(ctx, "cannot report commit status, the secret does not exist", namespace, request.Namespace, name, request.Name, "secret", statusReport.Secret)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	apiURL, err := scm.APIURL(statusReport.Provider, repository, statusReport.APIURL, r.config.StatusReport.AllowedAPIHosts)
+	if err != nil {
+		ctxlog.Info(ctx, "cannot report commit status", namespace, request.Namespace, name, request.Name, "error", err)
+		return reconcile.Result{}, nil
+	}
+
+	statusClient, err := r.newStatusClient(statusReport.Provider, apiURL, token)
+	if err != nil {
+		ctxlog.Info(ctx, "cannot report commit status", namespace, request.Namespace, name, request.Name, "error", err)
+		return reconcile.Result{}, nil
+	}
+
+	if err := statusClient.SetStatus(ctx, repository, sha, status); err != nil {
+		ctxlog.Error(ctx, err, "failed to report commit status", namespace, request.Namespace, name, request.Name, "commit", sha)
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Info(ctx, "reported commit status", namespace, request.Namespace, name, request.Name, "commit", sha, "state", status.State)
+
+	// remember the reported state, so that it is not reported again
+	original := buildRun.DeepCopy()
+	if buildRun.Annotations == nil {
+		buildRun.Annotations = map[string]string{}
+	}
+	buildRun.Annotations[build.AnnotationCommitStatus] = string(status.State)
+	if err := r.client.Patch(ctx, buildRun, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Debug(ctx, "finishing reconciling commit status", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
+
+// token returns the API token stored in the secret
+func (r *ReconcileCommitStatus) token(ctx context.Context, secretNamespace string, secretName string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: secretName}, secret); err != nil {
+		return "", err
+	}
+
+	token, ok := secret.Data[build.GitStatusReportTokenKey]
+	if !ok {
+		return "", fmt.Errorf("the secret %s does not contain the key %q", secretName, build.GitStatusReportTokenKey)
+	}
+
+	return string(token), nil
+}
+
+// reportsStatus returns true if the resolved Build spec of the BuildRun has a Git source with status report
+func reportsStatus(buildRun *build.BuildRun) bool {
+	spec := buildRun.Status.BuildSpec
+	return spec != nil && spec.Source != nil && spec.Source.Git != nil && spec.Source.Git.StatusReport != nil
+}
+
+// commitSHAOf returns the commit the BuildRun builds, which is either the commit the controller resolved
+// the Git revision to, the commit reported by the source step, or the commit SHA the BuildRun is pinned to
+func commitSHAOf(buildRun *build.BuildRun) string {
+	if buildRun.Status.ResolvedGitRevision != nil && buildRun.Status.ResolvedGitRevision.CommitSha != "" {
+		return buildRun.Status.ResolvedGitRevision.CommitSha
+	}
+
+	if buildRun.Status.Source != nil && buildRun.Status.Source.Git != nil && buildRun.Status.Source.Git.CommitSha != "" {
+		return buildRun.Status.Source.Git.CommitSha
+	}
+
+	if buildRun.Spec.Source != nil && buildRun.Spec.Source.Git != nil && buildRun.Spec.Source.Git.Revision != nil && commitSHA.MatchString(*buildRun.Spec.Source.Git.Revision) {
+		return *buildRun.Spec.Source.Git.Revision
+	}
+
+	if revision := buildRun.Status.BuildSpec.Source.Git.Revision; revision != nil && commitSHA.MatchString(*revision) {
+		return *revision
+	}
+
+	return ""
+}
+
+// commitStatus returns the commit status matching the Succeeded condition of the BuildRun
+func commitStatus(buildRun *build.BuildRun, statusReport *build.GitStatusReport) scm.Status {
+	status := scm.Status{
+		State:       scm.StatePending,
+		Context:     "shipwright/" + buildRun.Spec.BuildName(),
+		Description: fmt.Sprintf("The BuildRun %s is running", buildRun.Name),
+	}
+	if buildRun.Spec.BuildName() == "" {
+		status.Context = "shipwright"
+	}
+	if statusReport.Context != nil && *statusReport.Context != "" {
+		status.Context = *statusReport.Context
+	}
+
+	if condition := buildRun.Status.GetCondition(build.Succeeded); condition != nil {
+		switch condition.GetStatus() {
+		case corev1.ConditionTrue:
+			status.State = scm.StateSuccess
+			status.Description = fmt.Sprintf("The BuildRun %s succeeded", buildRun.Name)
+
+		case corev1.ConditionFalse:
+			status.State = scm.StateFailure
+			status.Description = fmt.Sprintf("The BuildRun %s failed: %s", buildRun.Name, condition.GetReason())
+		}
+	}
+
+	if len(status.Description) > maxDescriptionLength {
+		status.Description = status.Description[:maxDescriptionLength-3] + "..."
+	}
+
+	return status
+}
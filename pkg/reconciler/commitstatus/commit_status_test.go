@@ -0,0 +1,261 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/commitstatus"
+	"github.com/shipwright-io/build/pkg/scm"
+)
+
+const sha = "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"
+
+type reportedStatus struct {
+	Path          string
+	Authorization string
+	Payload       map[string]string
+}
+
+var _ = Describe("Reconcile commit status", func() {
+	var (
+		server     *httptest.Server
+		cfg        *config.Config
+		reported   []reportedStatus
+		buildRun   *build.BuildRun
+		secret     *corev1.Secret
+		client     *fakes.FakeClient
+		reconciler reconcile.Reconciler
+		request    reconcile.Request
+	)
+
+	setCondition := func(status corev1.ConditionStatus, reason string) {
+		buildRun.Status.Conditions = build.Conditions{{
+			Type:   build.Succeeded,
+			Status: status,
+			Reason: reason,
+		}}
+	}
+
+	BeforeEach(func() {
+		reported = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := reportedStatus{Path: r.URL.Path, Authorization: r.Header.Get("Authorization")}
+			Expect(json.NewDecoder(r.Body).Decode(&status.Payload)).To(Succeed())
+			reported = append(reported, status)
+			w.WriteHeader(http.StatusCreated)
+		}))
+		DeferCleanup(server.Close)
+
+		buildRun = &build.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-go-abcde",
+				Namespace: "default",
+			},
+			Spec: build.BuildRunSpec{
+				Build: build.ReferencedBuild{Name: ptr.To("sample-go")},
+				Source: &build.BuildRunSource{
+					Type: build.GitType,
+					Git:  &build.BuildRunGit{Revision: ptr.To(sha)},
+				},
+			},
+			Status: build.BuildRunStatus{
+				BuildSpec: &build.BuildSpec{
+					Source: &build.Source{
+						Type: build.GitType,
+						Git: &build.Git{
+							URL: "https://github.com/shipwright-io/sample-go",
+							StatusReport: &build.GitStatusReport{
+								Provider: build.GitHubProvider,
+								Secret:   "scm-token",
+								APIURL:   ptr.To(server.URL),
+							},
+						},
+					},
+				},
+			},
+		}
+		setCondition(corev1.ConditionUnknown, "Running")
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "scm-token", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("secret-token")},
+		}
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildRun.Name, Namespace: buildRun.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *build.BuildRun:
+				buildRun.DeepCopyInto(object)
+				return nil
+			case *corev1.Secret:
+				if secret != nil && secret.Name == nn.Name {
+					secret.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+
+		// keep the annotations of the sample in sync, like the API server would do
+		client.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.PatchOption) error {
+			buildRun.Annotations = object.GetAnnotations()
+			return nil
+		})
+
+		// the test server is not on the host of the repository
+		cfg = config.NewDefaultConfig()
+		cfg.StatusReport.AllowedAPIHosts = []string{server.Listener.Addr().String()}
+
+		manager := &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+		reconciler = commitstatus.NewReconciler(cfg, manager)
+	})
+
+	It("reports a running BuildRun as pending", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Path).To(Equal("/repos/shipwright-io/sample-go/statuses/" + sha))
+		Expect(reported[0].Authorization).To(Equal("Bearer secret-token"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("state", "pending"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("context", "shipwright/sample-go"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationCommitStatus, "pending"))
+	})
+
+	It("does not report the same status twice", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+	})
+
+	It("reports the commit of the source results once the BuildRun succeeded", func() {
+		buildRun.Spec.Source = nil
+		buildRun.Status.Source = &build.SourceResult{Git: &build.GitSourceResult{CommitSha: "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"}}
+		setCondition(corev1.ConditionTrue, "Succeeded")
+		buildRun.Status.BuildSpec.Source.Git.StatusReport.Context = ptr.To("ci/image")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Path).To(Equal("/repos/shipwright-io/sample-go/statuses/9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("state", "success"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("context", "ci/image"))
+	})
+
+	It("reports a failed BuildRun as failure", func() {
+		buildRun.Annotations = map[string]string{build.AnnotationCommitStatus: "pending"}
+		setCondition(corev1.ConditionFalse, "Failed")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("state", "failure"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("description", "The BuildRun sample-go-abcde failed: Failed"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationCommitStatus, "failure"))
+	})
+
+	It("waits for the commit when the BuildRun is not pinned to one", func() {
+		buildRun.Spec.Source = nil
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reported).To(BeEmpty())
+	})
+
+	It("reports a running BuildRun of a branch as pending once the source step reported the commit", func() {
+		buildRun.Spec.Source = nil
+		buildRun.Status.Source = &build.SourceResult{Git: &build.GitSourceResult{CommitSha: "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Path).To(Equal("/repos/shipwright-io/sample-go/statuses/9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("state", "pending"))
+	})
+
+	It("reports a pending BuildRun of a branch once the controller resolved the commit", func() {
+		buildRun.Spec.Source = nil
+		buildRun.Status.ResolvedGitRevision = &build.ResolvedGitRevision{
+			Revision:  "main",
+			Ref:       "refs/heads/main",
+			CommitSha: "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d",
+		}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Path).To(Equal("/repos/shipwright-io/sample-go/statuses/9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+		Expect(reported[0].Payload).To(HaveKeyWithValue("state", "pending"))
+	})
+
+	It("ignores BuildRuns of Builds that do not report their status", func() {
+		buildRun.Status.BuildSpec.Source.Git.StatusReport = nil
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reported).To(BeEmpty())
+		Expect(client.GetCallCount()).To(Equal(1))
+	})
+
+	It("does not report the status when the secret does not exist", func() {
+		secret = nil
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reported).To(BeEmpty())
+	})
+
+	It("does not report the status to an API URL that is not on an allowed host", func() {
+		cfg.StatusReport.AllowedAPIHosts = []string{"git-api.example.com"}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reported).To(BeEmpty())
+		Expect(buildRun.Annotations).ToNot(HaveKey(build.AnnotationCommitStatus))
+	})
+
+	It("uses the client of the status client factory", func() {
+		var provider build.GitProvider
+		manager := &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+		reconciler = commitstatus.NewReconcilerWithStatusClientFactory(cfg, manager, func(p build.GitProvider, apiURL string, token string) (scm.StatusClient, error) {
+			provider = p
+			return scm.NewStatusClient(build.GitLabProvider, apiURL, token)
+		})
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(provider).To(Equal(build.GitHubProvider))
+		Expect(reported).To(HaveLen(1))
+		Expect(reported[0].Path).To(Equal("/projects/shipwright-io/sample-go/statuses/" + sha))
+	})
+})
@@ -0,0 +1,85 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new commit status Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+	ctx = ctxlog.NewContext(ctx, "commit-status-controller")
+	return add(ctx, mgr, NewReconciler(c, mgr), c.Controllers.BuildRun.MaxConcurrentReconciles)
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(_ context.Context, mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	// Create a new controller
+	c, err := controller.New("commit-status-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	pred := predicate.TypedFuncs[*build.BuildRun]{
+		CreateFunc: func(e event.TypedCreateEvent[*build.BuildRun]) bool {
+			// Existing BuildRuns are reconciled on start, the annotation prevents reporting a status twice
+			return reportsStatus(e.Object)
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*build.BuildRun]) bool {
+			o := e.ObjectOld
+			n := e.ObjectNew
+
+			if !reportsStatus(n) {
+				return false
+			}
+
+			// Reconcile when the Build spec got resolved, when the source step reported the commit of
+			// a branch or tag, or when the BuildRun status changed
+			return !reportsStatus(o) || commitSHAOf(o) != commitSHAOf(n) || succeededStatus(o) != succeededStatus(n)
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*build.BuildRun]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes to BuildRuns
+	return c.Watch(source.Kind(mgr.GetCache(), &build.BuildRun{}, &handler.TypedEnqueueRequestForObject[*build.BuildRun]{}, pred))
+}
+
+// succeededStatus returns the status of the Succeeded condition of the BuildRun
+func succeededStatus(buildRun *build.BuildRun) corev1.ConditionStatus {
+	if condition := buildRun.Status.GetCondition(build.Succeeded); condition != nil {
+		return condition.GetStatus()
+	}
+	return ""
+}
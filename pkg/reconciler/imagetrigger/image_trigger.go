@@ -0,0 +1,245 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger
+
+import (
+	"context"
+	"fmt"
+
+	gcrname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/image"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// ReconcileImageTrigger reconciles Builds with Image triggers. It resolves the digests of the
+// watched images and creates a BuildRun when one of them changed since it was last checked.
+type ReconcileImageTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileImageTrigger{
+		config: c,
+		client: mgr.GetClient(),
+	}
+}
+
+// Reconcile checks the images of the Image triggers of a Build and requeues the Build so that
+// the images are checked again after the configured poll interval
+func (r *ReconcileImageTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling image triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &build.Build{}
+	if err := r.client.Get(ctx, request.NamespacedName, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling image triggers. build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	original := b.DeepCopy()
+
+	if !hasImageTriggers(b) {
+		// forget about images that are no longer watched
+		if len(b.Status.ImageTriggers) > 0 {
+			b.Status.ImageTriggers = nil
+			if err := r.client.Status().Patch(ctx, b, client.MergeFrom(original)); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	// only registered Builds can be triggered, the Build is checked again once it is registered
+	if b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+		ctxlog.Debug(ctx, "finish reconciling image triggers. build is not registered", namespace, request.Namespace, name, request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	lastSeen := map[string]build.ImageTriggerStatus{}
+	for _, imageTrigger := range b.Status.ImageTriggers {
+		lastSeen[imageTrigger.Name] = imageTrigger
+	}
+
+	var (
+		statuses     []build.ImageTriggerStatus
+		triggered    *build.TriggerWhen
+		triggeredKey string
+		checked      = map[string]struct{}{}
+		now          = metav1.Now()
+	)
+
+	for i := range b.Spec.Trigger.When {
+		when := &b.Spec.Trigger.When[i]
+		if when.Type != build.ImageTrigger || when.Image == nil {
+			continue
+		}
+
+		for _, imageName := range when.Image.Names {
+			if _, ok := checked[imageName]; ok {
+				continue
+			}
+			checked[imageName] = struct{}{}
+
+			previous, seen := lastSeen[imageName]
+
+			digest, err := r.resolveDigest(ctx, b, when.Image, imageName)
+			if err != nil {
+				ctxlog.Info(ctx, "failed to resolve the digest of a watched image", namespace, b.Namespace, name, b.Name, "image", imageName, "error", err)
+				if seen {
+					statuses = append(statuses, previous)
+				}
+				continue
+			}
+
+			// the first digest of an image is only recorded, a Build is triggered when the image changes afterwards
+			if seen && previous.Digest != "" && previous.Digest != digest && triggered == nil {
+				ctxlog.Info(ctx, "watched image changed", namespace, b.Namespace, name, b.Name, "image", imageName, "previous", previous.Digest, "digest", digest)
+				triggered = when
+				triggeredKey = imageName + "@" + digest
+			}
+
+			statuses = append(statuses, build.ImageTriggerStatus{
+				Name:          imageName,
+				Digest:        digest,
+				LastCheckTime: &now,
+			})
+		}
+	}
+
+	if triggered != nil {
+		// the BuildRun is named after the new digest, it already exists when the status of the Build
+		// could not be updated after creating it
+		buildRun := trigger.NewBuildRun(b, triggered, &trigger.Event{Type: build.ImageTrigger})
+		buildRun.GenerateName = ""
+		buildRun.Name = trigger.BuildRunName(b, triggeredKey)
+
+		switch err := r.client.Create(ctx, buildRun); {
+		case apierrors.IsAlreadyExists(err):
+			ctxlog.Debug(ctx, "BuildRun for the image already exists", namespace, b.Namespace, name, b.Name, "trigger", triggered.Name, "buildrun", buildRun.Name)
+
+		case err != nil:
+			return reconcile.Result{}, err
+
+		default:
+			ctxlog.Info(ctx, "created BuildRun for trigger", namespace, b.Namespace, name, b.Name, "trigger", triggered.Name, "buildrun", buildRun.Name)
+		}
+	}
+
+	b.Status.ImageTriggers = statuses
+	if err := r.client.Status().Patch(ctx, b, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Debug(ctx, "finishing reconciling image triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{RequeueAfter: r.config.Triggers.ImagePollInterval}, nil
+}
+
+// resolveDigest returns the digest of the image using the pull secret and insecure setting of the trigger,
+// images without a pull secret that are hosted in the output registry are accessed using the push secret
+func (r *ReconcileImageTrigger) resolveDigest(ctx context.Context, b *build.Build, whenImage *build.WhenImage, imageName string) (string, error) {
+	insecure := whenImage.Insecure != nil && *whenImage.Insecure
+
+	var nameOptions []gcrname.Option
+	if insecure {
+		nameOptions = append(nameOptions, gcrname.Insecure)
+	}
+
+	ref, err := gcrname.ParseReference(imageName, nameOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	secretName := whenImage.PullSecret
+	if secretName == nil && b.Spec.Output.PushSecret != nil && sameRegistry(ref, b.Spec.Output.Image) {
+		secretName = b.Spec.Output.PushSecret
+	}
+
+	// the credentials of the pull secret are only kept in memory
+	var options []remote.Option
+	if secretName == nil {
+		options, _, err = image.GetOptions(ctx, ref, insecure, "", "Shipwright Build")
+	} else {
+		var dockerConfigJSON []byte
+		if dockerConfigJSON, err = r.dockerConfigJSON(ctx, b.Namespace, *secretName); err != nil {
+			return "", err
+		}
+
+		options, _, err = image.GetOptionsForDockerConfigJSON(ctx, ref, insecure, dockerConfigJSON, "Shipwright Build")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	descriptor, err := remote.Head(ref, options...)
+	if err != nil {
+		return "", err
+	}
+
+	return descriptor.Digest.String(), nil
+}
+
+// dockerConfigJSON returns the Docker config of the pull secret
+func (r *ReconcileImageTrigger) dockerConfigJSON(ctx context.Context, secretNamespace string, secretName string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: secretName}, secret); err != nil {
+		return nil, err
+	}
+
+	data, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("the secret %s does not contain the key %q", secretName, corev1.DockerConfigJsonKey)
+	}
+
+	return data, nil
+}
+
+// hasImageTriggers returns true if the Build defines at least one Image trigger
+func hasImageTriggers(b *build.Build) bool {
+	if b.Spec.Trigger == nil {
+		return false
+	}
+
+	for _, when := range b.Spec.Trigger.When {
+		if when.Type == build.ImageTrigger && when.Image != nil && len(when.Image.Names) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameRegistry returns true if the output image is hosted in the registry of the reference
+func sameRegistry(ref gcrname.Reference, outputImage string) bool {
+	output, err := gcrname.ParseReference(outputImage, gcrname.WeakValidation)
+	if err != nil {
+		return false
+	}
+
+	return output.Context().RegistryStr() == ref.Context().RegistryStr()
+}
@@ -0,0 +1,257 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+)
+
+var _ = Describe("Reconcile image triggers", func() {
+	var (
+		registryHost string
+		imageName    string
+		buildSample  *build.Build
+		secret       *corev1.Secret
+		buildRuns    []*build.BuildRun
+		client       *fakes.FakeClient
+		statusWriter *fakes.FakeStatusWriter
+		reconciler   reconcile.Reconciler
+		request      reconcile.Request
+	)
+
+	pushRandomImage := func() {
+		ref, err := name.ParseReference(imageName)
+		Expect(err).ToNot(HaveOccurred())
+
+		img, err := random.Image(1024, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(remote.Write(ref, img)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		server := httptest.NewServer(reg)
+		DeferCleanup(func() {
+			server.Close()
+		})
+		registryHost = strings.ReplaceAll(server.URL, "http://", "")
+		imageName = fmt.Sprintf("%s/base/image:latest", registryHost)
+
+		pushRandomImage()
+
+		buildSample = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "image-triggered",
+				Namespace: "default",
+			},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name: "base image changed",
+						Type: build.ImageTrigger,
+						Image: &build.WhenImage{
+							Names:    []string{imageName},
+							Insecure: ptr.To(true),
+						},
+					}},
+				},
+			},
+			Status: build.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+			},
+		}
+		secret = nil
+		buildRuns = nil
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildSample.Name, Namespace: buildSample.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *build.Build:
+				buildSample.DeepCopyInto(object)
+				return nil
+			case *corev1.Secret:
+				if secret != nil && secret.Name == nn.Name {
+					secret.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			if buildRun, ok := object.(*build.BuildRun); ok {
+				buildRuns = append(buildRuns, buildRun)
+			}
+			return nil
+		})
+
+		// keep the status of the sample in sync, like the API server would do
+		statusWriter = &fakes.FakeStatusWriter{}
+		statusWriter.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.SubResourcePatchOption) error {
+			buildSample.Status = object.(*build.Build).Status
+			return nil
+		})
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+
+		manager := &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+		reconciler = imagetrigger.NewReconciler(config.NewDefaultConfig(), manager)
+	})
+
+	It("records the digest of a new image without triggering a build", func() {
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(300 * time.Second))
+
+		Expect(buildRuns).To(BeEmpty())
+		Expect(buildSample.Status.ImageTriggers).To(HaveLen(1))
+		Expect(buildSample.Status.ImageTriggers[0].Name).To(Equal(imageName))
+		Expect(buildSample.Status.ImageTriggers[0].Digest).To(HavePrefix("sha256:"))
+	})
+
+	It("does not trigger a build when the image did not change", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		digest := buildSample.Status.ImageTriggers[0].Digest
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(BeEmpty())
+		Expect(buildSample.Status.ImageTriggers[0].Digest).To(Equal(digest))
+	})
+
+	It("triggers a build when the image changed", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		digest := buildSample.Status.ImageTriggers[0].Digest
+
+		pushRandomImage()
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal(buildSample.Name))
+		Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(build.AnnotationTriggerType, string(build.ImageTrigger)))
+		Expect(buildRuns[0].Spec.Source).To(BeNil())
+		Expect(buildSample.Status.ImageTriggers[0].Digest).ToNot(Equal(digest))
+	})
+
+	It("names the BuildRun after the digest and tolerates that it already exists", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		pushRandomImage()
+
+		// the status update after creating the BuildRun fails, the image is seen as changed again
+		statusWriter.PatchReturns(errors.NewConflict(schema.GroupResource{}, buildSample.Name, nil))
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].GenerateName).To(BeEmpty())
+		Expect(buildRuns[0].Name).To(HavePrefix("image-triggered-"))
+
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			Expect(object.GetName()).To(Equal(buildRuns[0].Name))
+			return errors.NewAlreadyExists(schema.GroupResource{}, object.GetName())
+		})
+		statusWriter.PatchReturns(nil)
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("ignores builds that are not registered", func() {
+		buildSample.Status.Registered = ptr.To(corev1.ConditionFalse)
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(statusWriter.PatchCallCount()).To(Equal(0))
+	})
+
+	It("keeps the last seen digest when the image cannot be resolved", func() {
+		buildSample.Status.ImageTriggers = []build.ImageTriggerStatus{{Name: imageName, Digest: "sha256:previous"}}
+		buildSample.Spec.Trigger.When[0].Image.PullSecret = ptr.To("does-not-exist")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(BeEmpty())
+		Expect(buildSample.Status.ImageTriggers).To(Equal([]build.ImageTriggerStatus{{Name: imageName, Digest: "sha256:previous"}}))
+	})
+
+	It("uses the credentials of the pull secret", func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "default"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{%q:{"username":"user","password":"pass"}}}`, registryHost)),
+			},
+		}
+		buildSample.Spec.Trigger.When[0].Image.PullSecret = ptr.To("registry-credentials")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildSample.Status.ImageTriggers).To(HaveLen(1))
+		Expect(buildSample.Status.ImageTriggers[0].Digest).To(HavePrefix("sha256:"))
+	})
+
+	It("uses the push secret for images in the output registry", func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "push-credentials", Namespace: "default"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"other.registry.io":{"username":"user","password":"pass"}}}`),
+			},
+		}
+		buildSample.Spec.Output = build.Image{
+			Image:      fmt.Sprintf("%s/app/image:latest", registryHost),
+			PushSecret: ptr.To("push-credentials"),
+		}
+
+		// the push secret has no credentials for the registry, resolving the image fails
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.GetCallCount()).To(Equal(2))
+		Expect(buildSample.Status.ImageTriggers).To(BeEmpty())
+	})
+
+	It("removes the status when the build no longer has image triggers", func() {
+		buildSample.Status.ImageTriggers = []build.ImageTriggerStatus{{Name: imageName, Digest: "sha256:previous"}}
+		buildSample.Spec.Trigger = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(buildSample.Status.ImageTriggers).To(BeEmpty())
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger
+
+import (
+	"context"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new pipeline trigger Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+	ctx = ctxlog.NewContext(ctx, "pipeline-trigger-controller")
+	return add(ctx, mgr, NewReconciler(c, mgr), c.Controllers.Build.MaxConcurrentReconciles)
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(_ context.Context, mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	// Create a new controller
+	c, err := controller.New("pipeline-trigger-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	pred := predicate.TypedFuncs[*pipelineapi.PipelineRun]{
+		CreateFunc: func(_ event.TypedCreateEvent[*pipelineapi.PipelineRun]) bool {
+			// Ignore creations, otherwise all existing PipelineRuns would trigger builds when the controller starts
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*pipelineapi.PipelineRun]) bool {
+			o := e.ObjectOld.Status.GetCondition(apis.ConditionSucceeded)
+			n := e.ObjectNew.Status.GetCondition(apis.ConditionSucceeded)
+
+			// Only reconcile when the PipelineRun status changed
+			if n == nil {
+				return false
+			}
+
+			return o == nil || o.Status != n.Status || o.Reason != n.Reason
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*pipelineapi.PipelineRun]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes of Tekton PipelineRuns
+	return c.Watch(source.Kind(mgr.GetCache(), &pipelineapi.PipelineRun{}, &handler.TypedEnqueueRequestForObject[*pipelineapi.PipelineRun]{}, pred))
+}
@@ -0,0 +1,141 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger
+
+import (
+	"context"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// ReconcilePipelineTrigger reconciles Tekton PipelineRuns. It creates a BuildRun for every Build
+// with a Pipeline trigger matching the PipelineRun once it reaches one of the listed statuses.
+type ReconcilePipelineTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePipelineTrigger{
+		config: c,
+		client: mgr.GetClient(),
+	}
+}
+
+// Reconcile reads the status of a PipelineRun and triggers the Builds in its namespace that watch for it
+func (r *ReconcilePipelineTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling pipeline triggers", namespace, request.Namespace, name, request.Name)
+
+	pipelineRun := &pipelineapi.PipelineRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, pipelineRun); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling pipeline triggers. pipelinerun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	statuses := pipelineRunStatuses(pipelineRun)
+	if len(statuses) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	names := []string{pipelineRun.Name}
+	if pipelineRun.Spec.PipelineRef != nil && pipelineRun.Spec.PipelineRef.Name != "" {
+		names = append(names, pipelineRun.Spec.PipelineRef.Name)
+	}
+
+	buildList := &build.BuildList{}
+	if err := r.client.List(ctx, buildList, client.InNamespace(pipelineRun.Namespace)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	event := &trigger.Event{
+		Type: build.PipelineTrigger,
+		Object: &corev1.ObjectReference{
+			APIVersion: pipelineapi.SchemeGroupVersion.String(),
+			Kind:       "PipelineRun",
+			Namespace:  pipelineRun.Namespace,
+			Name:       pipelineRun.Name,
+			UID:        pipelineRun.UID,
+		},
+	}
+
+	for i := range buildList.Items {
+		b := &buildList.Items[i]
+
+		// only registered Builds can be triggered
+		if b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+			continue
+		}
+
+		when := trigger.MatchObjectRef(b, build.PipelineTrigger, names, pipelineRun.Labels, statuses)
+		if when == nil {
+			continue
+		}
+
+		// a PipelineRun triggers a Build only once, even when it reaches multiple listed statuses, the
+		// BuildRun is therefore named after the PipelineRun and it already exists for a second status
+		buildRun := trigger.NewBuildRun(b, when, event)
+		buildRun.GenerateName = ""
+		buildRun.Name = trigger.BuildRunName(b, string(pipelineRun.UID))
+
+		if err := r.client.Create(ctx, buildRun); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				ctxlog.Debug(ctx, "BuildRun for the PipelineRun already exists", namespace, b.Namespace, name, b.Name, "pipelinerun", pipelineRun.Name, "buildrun", buildRun.Name)
+				continue
+			}
+
+			return reconcile.Result{}, err
+		}
+
+		ctxlog.Info(ctx, "created BuildRun for trigger", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "pipelinerun", pipelineRun.Name, "buildrun", buildRun.Name)
+	}
+
+	ctxlog.Debug(ctx, "finishing reconciling pipeline triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
+
+// pipelineRunStatuses returns the statuses a PipelineRun is in, that is the reason of its Succeeded
+// condition, and Succeeded or Failed once it completed
+func pipelineRunStatuses(pipelineRun *pipelineapi.PipelineRun) []string {
+	condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil {
+		return nil
+	}
+
+	var statuses []string
+	if condition.Reason != "" {
+		statuses = append(statuses, condition.Reason)
+	}
+
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		statuses = append(statuses, "Succeeded")
+	case corev1.ConditionFalse:
+		statuses = append(statuses, "Failed")
+	}
+
+	return statuses
+}
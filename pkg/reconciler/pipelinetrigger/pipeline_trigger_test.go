@@ -0,0 +1,191 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	knativeapi "knative.dev/pkg/apis"
+	knativev1 "knative.dev/pkg/apis/duck/v1"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/pipelinetrigger"
+)
+
+var _ = Describe("Reconcile pipeline triggers", func() {
+	var (
+		pipelineRun *pipelineapi.PipelineRun
+		builds      []build.Build
+		buildRuns   []*build.BuildRun
+		client      *fakes.FakeClient
+		reconciler  reconcile.Reconciler
+		request     reconcile.Request
+	)
+
+	pipelineTriggeredBuild := func(name string, objectRef *build.WhenObjectRef) build.Build {
+		return build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name:      "tests passed",
+						Type:      build.PipelineTrigger,
+						ObjectRef: objectRef,
+					}},
+				},
+			},
+			Status: build.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		pipelineRun = &pipelineapi.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "unit-tests-x7k2p",
+				Namespace: "default",
+				UID:       "2a3c8f4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b",
+				Labels:    map[string]string{"app": "sample-go"},
+			},
+			Spec: pipelineapi.PipelineRunSpec{
+				PipelineRef: &pipelineapi.PipelineRef{Name: "unit-tests"},
+			},
+			Status: pipelineapi.PipelineRunStatus{
+				Status: knativev1.Status{
+					Conditions: knativev1.Conditions{{
+						Type:   knativeapi.ConditionSucceeded,
+						Reason: string(pipelineapi.PipelineRunReasonSuccessful),
+						Status: corev1.ConditionTrue,
+					}},
+				},
+			},
+		}
+
+		builds = []build.Build{
+			pipelineTriggeredBuild("by-name", &build.WhenObjectRef{Name: "unit-tests", Status: []string{"Succeeded"}}),
+			pipelineTriggeredBuild("by-selector", &build.WhenObjectRef{Selector: map[string]string{"app": "sample-go"}, Status: []string{"Succeeded"}}),
+			pipelineTriggeredBuild("on-failure", &build.WhenObjectRef{Name: "unit-tests", Status: []string{"Failed"}}),
+		}
+		buildRuns = nil
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: pipelineRun.Name, Namespace: pipelineRun.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *pipelineapi.PipelineRun:
+				if pipelineRun != nil {
+					pipelineRun.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			switch list := list.(type) {
+			case *build.BuildList:
+				list.Items = builds
+			}
+			return nil
+		})
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			if buildRun, ok := object.(*build.BuildRun); ok {
+				for _, existing := range buildRuns {
+					if existing.Name == buildRun.Name {
+						return errors.NewAlreadyExists(schema.GroupResource{}, buildRun.Name)
+					}
+				}
+				buildRuns = append(buildRuns, buildRun)
+			}
+			return nil
+		})
+
+		manager := &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+		reconciler = pipelinetrigger.NewReconciler(config.NewDefaultConfig(), manager)
+	})
+
+	It("creates BuildRuns for the Builds matching the PipelineRun", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(buildRuns).To(HaveLen(2))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal("by-name"))
+		Expect(buildRuns[1].Spec.BuildName()).To(Equal("by-selector"))
+
+		for _, buildRun := range buildRuns {
+			Expect(buildRun.Labels).To(HaveKeyWithValue(build.LabelTriggerObjectUID, "2a3c8f4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerObjectKind, "PipelineRun"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerObjectName, "unit-tests-x7k2p"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerType, string(build.PipelineTrigger)))
+		}
+	})
+
+	It("creates BuildRuns for failed PipelineRuns", func() {
+		pipelineRun.Status.Conditions[0].Status = corev1.ConditionFalse
+		pipelineRun.Status.Conditions[0].Reason = string(pipelineapi.PipelineRunReasonFailed)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal("on-failure"))
+	})
+
+	It("does not create a BuildRun for a running PipelineRun", func() {
+		pipelineRun.Status.Conditions[0].Status = corev1.ConditionUnknown
+		pipelineRun.Status.Conditions[0].Reason = string(pipelineapi.PipelineRunReasonRunning)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(BeEmpty())
+	})
+
+	It("does not trigger a Build twice for the same PipelineRun", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(2))
+		Expect(buildRuns[0].GenerateName).To(BeEmpty())
+		Expect(buildRuns[0].Name).To(HavePrefix("by-name-"))
+
+		// the PipelineRun is reconciled again before the cache contains the BuildRuns
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(2))
+	})
+
+	It("ignores Builds that are not registered", func() {
+		builds[0].Status.Registered = ptr.To(corev1.ConditionFalse)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal("by-selector"))
+	})
+
+	It("ignores PipelineRuns that no longer exist", func() {
+		pipelineRun = nil
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(client.ListCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,79 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new schedule trigger Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+	ctx = ctxlog.NewContext(ctx, "schedule-trigger-controller")
+	return add(ctx, mgr, NewReconciler(c, mgr), c.Controllers.Build.MaxConcurrentReconciles)
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(_ context.Context, mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	// Create a new controller
+	c, err := controller.New("schedule-trigger-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	pred := predicate.TypedFuncs[*build.Build]{
+		CreateFunc: func(e event.TypedCreateEvent[*build.Build]) bool {
+			return hasScheduleTriggers(e.Object)
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*build.Build]) bool {
+			o := e.ObjectOld
+			n := e.ObjectNew
+
+			if !hasScheduleTriggers(o) && !hasScheduleTriggers(n) {
+				return false
+			}
+
+			// Reconcile when the spec changed, or when the Build got registered
+			return o.GetGeneration() != n.GetGeneration() || isRegistered(o) != isRegistered(n)
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*build.Build]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes to primary resource Build
+	return c.Watch(source.Kind(mgr.GetCache(), &build.Build{}, &handler.TypedEnqueueRequestForObject[*build.Build]{}, pred))
+}
+
+func isRegistered(b *build.Build) bool {
+	return b.Status.Registered != nil && *b.Status.Registered == corev1.ConditionTrue
+}
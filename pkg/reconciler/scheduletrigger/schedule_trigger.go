@@ -0,0 +1,269 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// maxMissedSchedules limits how many missed activations are walked through to find the latest one,
+// the remaining activations are ignored when the controller was down for a long time
+const maxMissedSchedules = 1000
+
+// ReconcileScheduleTrigger reconciles Builds with Schedule triggers. It creates a BuildRun whenever
+// the cron schedule of a trigger is due and requeues the Build until the next activation.
+type ReconcileScheduleTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileScheduleTrigger{
+		config: c,
+		client: mgr.GetClient(),
+	}
+}
+
+// Reconcile creates BuildRuns for the Schedule triggers of a Build that are due and requeues the
+// Build for the next activation of its schedules
+func (r *ReconcileScheduleTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling schedule triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &build.Build{}
+	if err := r.client.Get(ctx, request.NamespacedName, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling schedule triggers. build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	original := b.DeepCopy()
+
+	if !hasScheduleTriggers(b) {
+		// forget about schedules that no longer exist
+		if len(b.Status.ScheduleTriggers) > 0 {
+			b.Status.ScheduleTriggers = nil
+			if err := r.client.Status().Patch(ctx, b, client.MergeFrom(original)); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	// only registered Builds can be triggered, the Build is checked again once it is registered
+	if b.Status.Registered == nil || *b.Status.Registered != corev1.ConditionTrue {
+		ctxlog.Debug(ctx, "finish reconciling schedule triggers. build is not registered", namespace, request.Namespace, name, request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	lastScheduled := map[string]*metav1.Time{}
+	for _, scheduleTrigger := range b.Status.ScheduleTriggers {
+		lastScheduled[scheduleTrigger.Name] = scheduleTrigger.LastScheduleTime
+	}
+
+	var (
+		statuses     []build.ScheduleTriggerStatus
+		requeueAfter time.Duration
+		now          = time.Now()
+	)
+
+	for i := range b.Spec.Trigger.When {
+		when := &b.Spec.Trigger.When[i]
+		if when.Type != build.ScheduleTrigger || when.Schedule == nil {
+			continue
+		}
+
+		schedule, err := trigger.ParseSchedule(when.Schedule)
+		if err != nil {
+			ctxlog.Info(ctx, "failed to parse the schedule of a trigger", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "error", err)
+			continue
+		}
+
+		// schedules start counting when the trigger is first observed, so that adding or renaming a
+		// trigger does not create a BuildRun right away
+		last := lastScheduled[when.Name]
+		if last == nil {
+			statuses = append(statuses, build.ScheduleTriggerStatus{Name: when.Name, LastScheduleTime: &metav1.Time{Time: now}})
+			if next := schedule.Next(now); !next.IsZero() && (requeueAfter == 0 || next.Sub(now) < requeueAfter) {
+				requeueAfter = next.Sub(now)
+			}
+
+			continue
+		}
+
+		// find the latest activation that is due, missed activations only result in a single BuildRun
+		var due time.Time
+		next := schedule.Next(last.Time)
+		for i := 0; !next.IsZero() && !next.After(now) && i < maxMissedSchedules; i++ {
+			due = next
+			next = schedule.Next(next)
+		}
+
+		// too many activations were missed, the remaining ones are skipped
+		if !next.IsZero() && !next.After(now) {
+			due = latestActivation(schedule, due, now)
+			next = schedule.Next(due)
+		}
+
+		status := build.ScheduleTriggerStatus{Name: when.Name, LastScheduleTime: last}
+		if !due.IsZero() {
+			if err := r.runSchedule(ctx, b, when, due); err != nil {
+				return reconcile.Result{}, err
+			}
+
+			status.LastScheduleTime = &metav1.Time{Time: due}
+		}
+		statuses = append(statuses, status)
+
+		if !next.IsZero() && (requeueAfter == 0 || next.Sub(now) < requeueAfter) {
+			requeueAfter = next.Sub(now)
+		}
+	}
+
+	b.Status.ScheduleTriggers = statuses
+	if err := r.client.Status().Patch(ctx, b, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Debug(ctx, "finishing reconciling schedule triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// latestActivation returns the latest activation of the schedule after the given time that is not after now. It
+// searches backwards from now in growing windows so that only the activations close to now are walked through.
+func latestActivation(schedule cron.Schedule, after time.Time, now time.Time) time.Time {
+	for window := time.Minute; ; window *= 2 {
+		start := now.Add(-window)
+		if start.Before(after) {
+			start = after
+		}
+
+		var latest time.Time
+		for next := schedule.Next(start); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+			latest = next
+		}
+
+		if !latest.IsZero() || start.Equal(after) {
+			return latest
+		}
+	}
+}
+
+// runSchedule creates the BuildRun for the activation of a Schedule trigger that is due, applying the concurrency
+// policy of the trigger when BuildRuns that were previously created by it are still active. The BuildRun is named
+// after the activation, it already exists when the status of the Build could not be updated after creating it.
+func (r *ReconcileScheduleTrigger) runSchedule(ctx context.Context, b *build.Build, when *build.TriggerWhen, due time.Time) error {
+	policy := trigger.ConcurrencyPolicy(when.Schedule)
+
+	buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Type: build.ScheduleTrigger})
+	buildRun.GenerateName = ""
+	buildRun.Name = trigger.BuildRunName(b, when.Name+"@"+due.UTC().Format(time.RFC3339))
+
+	if policy != build.ScheduleConcurrencyAllow {
+		active, err := r.activeBuildRuns(ctx, b, when, buildRun.Name)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(active) > 0 && policy == build.ScheduleConcurrencySkip:
+			ctxlog.Info(ctx, "skipping schedule, previous BuildRun is still active", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "buildrun", active[0].Name)
+			return nil
+
+		case len(active) > 0 && policy == build.ScheduleConcurrencyReplace:
+			for i := range active {
+				buildRun := &active[i]
+				original := buildRun.DeepCopy()
+				buildRun.Spec.State = build.BuildRunRequestedStatePtr(build.BuildRunStateCancel)
+				if err := r.client.Patch(ctx, buildRun, client.MergeFrom(original)); err != nil {
+					return err
+				}
+
+				ctxlog.Info(ctx, "canceled BuildRun replaced by schedule", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "buildrun", buildRun.Name)
+			}
+		}
+	}
+
+	if err := r.client.Create(ctx, buildRun); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			ctxlog.Debug(ctx, "BuildRun for the schedule already exists", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "buildrun", buildRun.Name)
+			return nil
+		}
+
+		return err
+	}
+
+	ctxlog.Info(ctx, "created BuildRun for trigger", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "buildrun", buildRun.Name)
+	return nil
+}
+
+// activeBuildRuns returns the BuildRuns of the Build created by the Schedule trigger that are neither done nor canceled,
+// except the one of the current activation
+func (r *ReconcileScheduleTrigger) activeBuildRuns(ctx context.Context, b *build.Build, when *build.TriggerWhen, current string) ([]build.BuildRun, error) {
+	buildRunList := &build.BuildRunList{}
+	if err := r.client.List(ctx, buildRunList, &client.ListOptions{
+		Namespace:     b.Namespace,
+		LabelSelector: labels.SelectorFromSet(labels.Set{build.LabelBuild: b.Name}),
+	}); err != nil {
+		return nil, err
+	}
+
+	var active []build.BuildRun
+	for _, buildRun := range buildRunList.Items {
+		if buildRun.Annotations[build.AnnotationTriggerType] != string(build.ScheduleTrigger) ||
+			buildRun.Annotations[build.AnnotationTriggerName] != when.Name {
+			continue
+		}
+
+		if buildRun.Name == current || buildRun.IsDone() || buildRun.IsCanceled() {
+			continue
+		}
+
+		active = append(active, buildRun)
+	}
+
+	return active, nil
+}
+
+// hasScheduleTriggers returns true if the Build defines at least one Schedule trigger
+func hasScheduleTriggers(b *build.Build) bool {
+	if b.Spec.Trigger == nil {
+		return false
+	}
+
+	for _, when := range b.Spec.Trigger.When {
+		if when.Type == build.ScheduleTrigger && when.Schedule != nil {
+			return true
+		}
+	}
+
+	return false
+}
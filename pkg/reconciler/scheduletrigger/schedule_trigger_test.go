@@ -0,0 +1,319 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
+)
+
+var _ = Describe("Reconcile schedule triggers", func() {
+	var (
+		buildSample       *build.Build
+		existingBuildRuns []build.BuildRun
+		buildRuns         []*build.BuildRun
+		canceledBuildRuns []string
+		client            *fakes.FakeClient
+		statusWriter      *fakes.FakeStatusWriter
+		reconciler        reconcile.Reconciler
+		request           reconcile.Request
+	)
+
+	scheduledBuildRun := func(name string, succeeded corev1.ConditionStatus) build.BuildRun {
+		return build.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Annotations: map[string]string{
+					build.AnnotationTriggerName: "nightly",
+					build.AnnotationTriggerType: string(build.ScheduleTrigger),
+				},
+			},
+			Status: build.BuildRunStatus{
+				Conditions: build.Conditions{{
+					Type:   build.Succeeded,
+					Status: succeeded,
+				}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		buildSample = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "schedule-triggered",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name: "nightly",
+						Type: build.ScheduleTrigger,
+						Schedule: &build.WhenSchedule{
+							Cron: "*/5 * * * *",
+						},
+					}},
+				},
+			},
+			Status: build.BuildStatus{
+				Registered: ptr.To(corev1.ConditionTrue),
+				ScheduleTriggers: []build.ScheduleTriggerStatus{{
+					Name:             "nightly",
+					LastScheduleTime: ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Hour))),
+				}},
+			},
+		}
+		existingBuildRuns = nil
+		buildRuns = nil
+		canceledBuildRuns = nil
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildSample.Name, Namespace: buildSample.Namespace}}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			if b, ok := object.(*build.Build); ok {
+				buildSample.DeepCopyInto(b)
+				return nil
+			}
+			return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			if list, ok := list.(*build.BuildRunList); ok {
+				list.Items = existingBuildRuns
+			}
+			return nil
+		})
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			if buildRun, ok := object.(*build.BuildRun); ok {
+				buildRuns = append(buildRuns, buildRun)
+			}
+			return nil
+		})
+		client.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.PatchOption) error {
+			if buildRun, ok := object.(*build.BuildRun); ok && buildRun.IsCanceled() {
+				canceledBuildRuns = append(canceledBuildRuns, buildRun.Name)
+			}
+			return nil
+		})
+
+		// keep the status of the sample in sync, like the API server would do
+		statusWriter = &fakes.FakeStatusWriter{}
+		statusWriter.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.SubResourcePatchOption) error {
+			buildSample.Status = object.(*build.Build).Status
+			return nil
+		})
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+
+		manager := &fakes.FakeManager{}
+		manager.GetClientReturns(client)
+		reconciler = scheduletrigger.NewReconciler(config.NewDefaultConfig(), manager)
+	})
+
+	It("creates a single BuildRun for missed schedules and requeues until the next one", func() {
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 5*time.Minute))
+
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal(buildSample.Name))
+		Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(build.AnnotationTriggerName, "nightly"))
+		Expect(buildRuns[0].Annotations).To(HaveKeyWithValue(build.AnnotationTriggerType, string(build.ScheduleTrigger)))
+
+		Expect(buildSample.Status.ScheduleTriggers).To(HaveLen(1))
+		Expect(buildSample.Status.ScheduleTriggers[0].Name).To(Equal("nightly"))
+		Expect(buildSample.Status.ScheduleTriggers[0].LastScheduleTime.Time).To(BeTemporally("~", time.Now(), 5*time.Minute))
+	})
+
+	It("records when a trigger is first observed without creating a BuildRun", func() {
+		buildSample.Status.ScheduleTriggers = []build.ScheduleTriggerStatus{{Name: "renamed", LastScheduleTime: ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Hour)))}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 5*time.Minute))
+		Expect(buildRuns).To(BeEmpty())
+
+		Expect(buildSample.Status.ScheduleTriggers).To(HaveLen(1))
+		Expect(buildSample.Status.ScheduleTriggers[0].Name).To(Equal("nightly"))
+		Expect(buildSample.Status.ScheduleTriggers[0].LastScheduleTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+	})
+
+	It("requeues until the next schedule when too many schedules were missed", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.Cron = "* * * * *"
+		buildSample.Status.ScheduleTriggers[0].LastScheduleTime = ptr.To(metav1.NewTime(time.Now().Add(-10 * 24 * time.Hour)))
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", time.Minute))
+		Expect(buildRuns).To(HaveLen(1))
+
+		// the latest activation is due, which is a full minute
+		lastScheduleTime := buildSample.Status.ScheduleTriggers[0].LastScheduleTime.Time
+		Expect(lastScheduleTime).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(lastScheduleTime).To(Equal(lastScheduleTime.Truncate(time.Minute)))
+	})
+
+	It("names the BuildRun after the latest activation when too many schedules were missed", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.Cron = "0 * * * *"
+		buildSample.Status.ScheduleTriggers[0].LastScheduleTime = ptr.To(metav1.NewTime(time.Now().Add(-365 * 24 * time.Hour)))
+		statusWriter.PatchReturns(errors.NewConflict(schema.GroupResource{}, buildSample.Name, nil))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+
+		Expect(buildRuns).To(HaveLen(2))
+		Expect(buildRuns[1].Name).To(Equal(buildRuns[0].Name))
+	})
+
+	It("names the BuildRun after the activation and tolerates that it already exists", func() {
+		statusWriter.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.SubResourcePatchOption) error {
+			if statusWriter.PatchCallCount() == 1 {
+				return errors.NewConflict(schema.GroupResource{}, buildSample.Name, nil)
+			}
+			buildSample.Status = object.(*build.Build).Status
+			return nil
+		})
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].GenerateName).To(BeEmpty())
+		Expect(buildRuns[0].Name).To(HavePrefix("schedule-triggered-"))
+
+		// the status was not updated, the same activation is due again
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			Expect(object.GetName()).To(Equal(buildRuns[0].Name))
+			return errors.NewAlreadyExists(schema.GroupResource{}, object.GetName())
+		})
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildSample.Status.ScheduleTriggers[0].LastScheduleTime).ToNot(BeNil())
+	})
+
+	It("does not create a BuildRun before the schedule is due", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.Cron = "0 3 * * *"
+		buildSample.Status.ScheduleTriggers = []build.ScheduleTriggerStatus{{Name: "nightly", LastScheduleTime: ptr.To(metav1.Now())}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		Expect(result.RequeueAfter).To(BeNumerically("<=", 24*time.Hour))
+		Expect(buildRuns).To(BeEmpty())
+	})
+
+	It("creates a BuildRun while a previous one is active when concurrency is allowed", func() {
+		existingBuildRuns = []build.BuildRun{scheduledBuildRun("schedule-triggered-abcde", corev1.ConditionUnknown)}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(client.ListCallCount()).To(Equal(0))
+	})
+
+	It("skips the schedule while a previous BuildRun is active", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = ptr.To(build.ScheduleConcurrencySkip)
+		existingBuildRuns = []build.BuildRun{scheduledBuildRun("schedule-triggered-abcde", corev1.ConditionUnknown)}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(BeEmpty())
+		Expect(buildSample.Status.ScheduleTriggers[0].LastScheduleTime).ToNot(BeNil())
+	})
+
+	It("runs the schedule when the previous BuildRun completed", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = ptr.To(build.ScheduleConcurrencySkip)
+		existingBuildRuns = []build.BuildRun{scheduledBuildRun("schedule-triggered-abcde", corev1.ConditionTrue)}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(HaveLen(1))
+	})
+
+	It("cancels the active BuildRuns when replacing them", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = ptr.To(build.ScheduleConcurrencyReplace)
+		existingBuildRuns = []build.BuildRun{
+			scheduledBuildRun("schedule-triggered-abcde", corev1.ConditionUnknown),
+			scheduledBuildRun("schedule-triggered-fghij", corev1.ConditionFalse),
+		}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(canceledBuildRuns).To(Equal([]string{"schedule-triggered-abcde"}))
+		Expect(buildRuns).To(HaveLen(1))
+	})
+
+	It("ignores active BuildRuns that were not created by the schedule", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = ptr.To(build.ScheduleConcurrencyReplace)
+		manual := scheduledBuildRun("schedule-triggered-manual", corev1.ConditionUnknown)
+		manual.Annotations = nil
+		existingBuildRuns = []build.BuildRun{manual}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(canceledBuildRuns).To(BeEmpty())
+		Expect(buildRuns).To(HaveLen(1))
+	})
+
+	It("interprets the schedule in the configured time zone", func() {
+		location, err := time.LoadLocation("Asia/Kolkata")
+		Expect(err).ToNot(HaveOccurred())
+
+		// the schedule was last due at the latest full hour of a time zone with a 30 minutes offset to UTC
+		lastScheduleTime := time.Now().Truncate(time.Hour).Add(30 * time.Minute)
+		if lastScheduleTime.After(time.Now()) {
+			lastScheduleTime = lastScheduleTime.Add(-time.Hour)
+		}
+		buildSample.Spec.Trigger.When[0].Schedule.Cron = "0 * * * *"
+		buildSample.Spec.Trigger.When[0].Schedule.TimeZone = ptr.To("Asia/Kolkata")
+		buildSample.Status.ScheduleTriggers = []build.ScheduleTriggerStatus{{Name: "nightly", LastScheduleTime: ptr.To(metav1.NewTime(lastScheduleTime))}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buildRuns).To(BeEmpty())
+		Expect(time.Now().Add(result.RequeueAfter).In(location).Minute()).To(Equal(0))
+	})
+
+	It("ignores builds that are not registered", func() {
+		buildSample.Status.Registered = ptr.To(corev1.ConditionFalse)
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(buildRuns).To(BeEmpty())
+		Expect(statusWriter.PatchCallCount()).To(Equal(0))
+	})
+
+	It("removes the status when the build no longer has schedule triggers", func() {
+		buildSample.Status.ScheduleTriggers = []build.ScheduleTriggerStatus{{Name: "nightly", LastScheduleTime: ptr.To(metav1.Now())}}
+		buildSample.Spec.Trigger = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(buildSample.Status.ScheduleTriggers).To(BeEmpty())
+	})
+})
@@ -0,0 +1,69 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scm contains clients for the APIs of Git services, like GitHub or GitLab.
+package scm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Repository identifies a repository hosted by a Git service
+type Repository struct {
+	// Host is the host name of the Git service
+	Host string
+
+	// Path is the full path of the repository without the .git suffix, for example shipwright-io/build
+	// or, for services supporting nested groups, group/subgroup/project
+	Path string
+}
+
+// ParseRepository returns the repository of a Git URL, both URLs and the scp-like syntax
+// (git@github.com:shipwright-io/build.git) are supported
+func ParseRepository(gitURL string) (*Repository, error) {
+	value := strings.TrimSpace(gitURL)
+
+	// scp-like syntax, for example git@github.com:org/repo.git
+	if !strings.Contains(value, "://") {
+		value = "ssh://" + strings.Replace(value, ":", "/", 1)
+	}
+
+	repoURL, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Git URL %q: %w", gitURL, err)
+	}
+
+	repoPath := strings.TrimSuffix(strings.Trim(repoURL.Path, "/"), ".git")
+	if repoURL.Hostname() == "" || !strings.Contains(repoPath, "/") {
+		return nil, fmt.Errorf("the Git URL %q does not point to a repository", gitURL)
+	}
+
+	return &Repository{
+		Host: repoURL.Hostname(),
+		Path: repoPath,
+	}, nil
+}
+
+// SameRepository checks whether both Git URLs point to the same repository, the host and the path are
+// compared case-insensitively so that the HTTPS and SSH URLs of a repository are the same
+func SameRepository(gitURL string, otherGitURL string) bool {
+	repository, err := ParseRepository(gitURL)
+	if err != nil {
+		return false
+	}
+
+	otherRepository, err := ParseRepository(otherGitURL)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(repository.Host, otherRepository.Host) && strings.EqualFold(repository.Path, otherRepository.Path)
+}
+
+// WebURL returns the HTTPS URL of the repository
+func (r *Repository) WebURL() string {
+	return fmt.Sprintf("https://%s/%s", r.Host, r.Path)
+}
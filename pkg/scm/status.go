@@ -0,0 +1,298 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/util"
+)
+
+// requestTimeout is the timeout of a request to the API of a Git service
+const requestTimeout = 30 * time.Second
+
+// State is the provider independent state of a commit status
+type State string
+
+const (
+	// StatePending is the state of a commit while its build is running
+	StatePending State = "pending"
+
+	// StateSuccess is the state of a commit once its build succeeded
+	StateSuccess State = "success"
+
+	// StateFailure is the state of a commit once its build failed or was canceled
+	StateFailure State = "failure"
+)
+
+// Status is a commit status reported to a Git service
+type Status struct {
+	// State is the state of the commit
+	State State
+
+	// Context is the name that identifies the status among the other statuses of the commit
+	Context string
+
+	// Description is a short human readable description of the status
+	Description string
+
+	// TargetURL links to details about the status, it is optional
+	TargetURL string
+}
+
+// StatusClient reports commit statuses to the API of a Git service
+type StatusClient interface {
+	// SetStatus creates or updates the status of the commit in the repository
+	SetStatus(ctx context.Context, repository *Repository, sha string, status Status) error
+}
+
+// StatusClientFactory returns a StatusClient for the provider that uses the given API URL and token
+type StatusClientFactory func(provider build.GitProvider, apiURL string, token string) (StatusClient, error)
+
+// NewStatusClient returns the StatusClient for the provider, using a HTTP client that trusts the CA bundle of the cluster
+func NewStatusClient(provider build.GitProvider, apiURL string, token string) (StatusClient, error) {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	switch provider {
+	case build.GitHubProvider:
+		return &githubClient{apiClient{apiURL: apiURL, httpClient: httpClient, header: http.Header{
+			"Authorization": []string{"Bearer " + token},
+			"Accept":        []string{"application/vnd.github+json"},
+		}}}, nil
+
+	case build.GitLabProvider:
+		return &gitlabClient{apiClient{apiURL: apiURL, httpClient: httpClient, header: http.Header{
+			"Private-Token": []string{token},
+		}}}, nil
+
+	case build.GiteaProvider:
+		return &giteaClient{apiClient{apiURL: apiURL, httpClient: httpClient, header: http.Header{
+			"Authorization": []string{"token " + token},
+		}}}, nil
+
+	case build.BitbucketProvider:
+		return &bitbucketClient{apiClient{apiURL: apiURL, httpClient: httpClient, header: http.Header{
+			"Authorization": []string{"Bearer " + token},
+		}}}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported Git provider %q", provider)
+	}
+}
+
+// DefaultAPIURL returns the API URL of the provider for the host of the repository
+func DefaultAPIURL(provider build.GitProvider, repository *Repository) string {
+	switch provider {
+	case build.GitHubProvider:
+		if repository.Host == "github.com" {
+			return "https://api.github.com"
+		}
+		// GitHub Enterprise Server
+		return fmt.Sprintf("https://%s/api/v3", repository.Host)
+
+	case build.GitLabProvider:
+		return fmt.Sprintf("https://%s/api/v4", repository.Host)
+
+	case build.GiteaProvider:
+		return fmt.Sprintf("https://%s/api/v1", repository.Host)
+
+	case build.BitbucketProvider:
+		return "https://api.bitbucket.org/2.0"
+
+	default:
+		return ""
+	}
+}
+
+// APIURL returns the API URL that commit statuses of the repository are reported to. The API URL of the
+// status report is only used if its host is the host of the repository, the host of the default API URL, or
+// one of the allowed hosts, so that the token is not sent to other servers.
+func APIURL(provider build.GitProvider, repository *Repository, apiURL *string, allowedHosts []string) (string, error) {
+	defaultAPIURL := DefaultAPIURL(provider, repository)
+	if apiURL == nil || *apiURL == "" {
+		return defaultAPIURL, nil
+	}
+
+	customURL, err := url.Parse(*apiURL)
+	if err != nil {
+		return "", err
+	}
+
+	if customURL.Scheme != "https" && customURL.Scheme != "http" {
+		return "", fmt.Errorf("the API URL %s does not use HTTP or HTTPS", customURL.Redacted())
+	}
+
+	hosts := []string{repository.Host}
+	if defaultURL, err := url.Parse(defaultAPIURL); err == nil {
+		hosts = append(hosts, defaultURL.Host)
+	}
+	hosts = append(hosts, allowedHosts...)
+
+	for _, host := range hosts {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+
+		// hosts without a port match any port
+		if strings.EqualFold(host, customURL.Host) || strings.EqualFold(host, customURL.Hostname()) {
+			return *apiURL, nil
+		}
+	}
+
+	return "", fmt.Errorf("the host of the API URL %s is neither the host of the repository nor an allowed API host", customURL.Redacted())
+}
+
+// newHTTPClient returns a HTTP client with a timeout that trusts the CA bundle of the cluster and does not
+// follow redirects, so that the token is not sent to other servers
+func newHTTPClient() (*http.Client, error) {
+	rootCAs, err := util.RootCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}, nil
+}
+
+// apiClient sends authenticated JSON requests to the API of a Git service
+type apiClient struct {
+	apiURL     string
+	httpClient *http.Client
+	header     http.Header
+}
+
+func (c *apiClient) post(ctx context.Context, path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range c.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request to %s failed with status %d: %s", req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// githubClient reports commit statuses using the GitHub REST API
+type githubClient struct {
+	apiClient
+}
+
+func (c *githubClient) SetStatus(ctx context.Context, repository *Repository, sha string, status Status) error {
+	return c.post(ctx, fmt.Sprintf("/repos/%s/statuses/%s", repository.Path, sha), map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	})
+}
+
+// gitlabClient reports commit statuses using the GitLab REST API
+type gitlabClient struct {
+	apiClient
+}
+
+func (c *gitlabClient) SetStatus(ctx context.Context, repository *Repository, sha string, status Status) error {
+	state := string(status.State)
+	if status.State == StateFailure {
+		state = "failed"
+	}
+
+	return c.post(ctx, fmt.Sprintf("/projects/%s/statuses/%s", url.PathEscape(repository.Path), sha), map[string]string{
+		"state":       state,
+		"name":        status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	})
+}
+
+// giteaClient reports commit statuses using the Gitea REST API
+type giteaClient struct {
+	apiClient
+}
+
+func (c *giteaClient) SetStatus(ctx context.Context, repository *Repository, sha string, status Status) error {
+	return c.post(ctx, fmt.Sprintf("/repos/%s/statuses/%s", repository.Path, sha), map[string]string{
+		"state":       string(status.State),
+		"context":     status.Context,
+		"description": status.Description,
+		"target_url":  status.TargetURL,
+	})
+}
+
+// bitbucketClient reports build statuses using the Bitbucket Cloud REST API
+type bitbucketClient struct {
+	apiClient
+}
+
+// bitbucketStates maps the states to the Bitbucket build states
+var bitbucketStates = map[State]string{
+	StatePending: "INPROGRESS",
+	StateSuccess: "SUCCESSFUL",
+	StateFailure: "FAILED",
+}
+
+func (c *bitbucketClient) SetStatus(ctx context.Context, repository *Repository, sha string, status Status) error {
+	// Bitbucket requires a URL, link to the repository if there are no details
+	targetURL := status.TargetURL
+	if targetURL == "" {
+		targetURL = repository.WebURL()
+	}
+
+	return c.post(ctx, fmt.Sprintf("/repositories/%s/commit/%s/statuses/build", repository.Path, sha), map[string]string{
+		"state":       bitbucketStates[status.State],
+		"key":         status.Context,
+		"name":        status.Context,
+		"description": status.Description,
+		"url":         targetURL,
+	})
+}
@@ -0,0 +1,187 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/scm"
+)
+
+const sha = "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"
+
+var _ = Describe("ParseRepository", func() {
+	It("parses HTTPS URLs", func() {
+		repository, err := scm.ParseRepository("https://gitlab.example.com/group/subgroup/project.git")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repository.Host).To(Equal("gitlab.example.com"))
+		Expect(repository.Path).To(Equal("group/subgroup/project"))
+		Expect(repository.WebURL()).To(Equal("https://gitlab.example.com/group/subgroup/project"))
+	})
+
+	It("parses the scp-like syntax", func() {
+		repository, err := scm.ParseRepository("git@github.com:shipwright-io/sample-go.git")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repository.Host).To(Equal("github.com"))
+		Expect(repository.Path).To(Equal("shipwright-io/sample-go"))
+	})
+
+	It("fails for URLs without repository", func() {
+		_, err := scm.ParseRepository("https://github.com/shipwright-io")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = DescribeTable("SameRepository",
+	func(gitURL string, otherGitURL string, expected bool) {
+		Expect(scm.SameRepository(gitURL, otherGitURL)).To(Equal(expected))
+	},
+	Entry("HTTPS and SSH URLs", "https://github.com/shipwright-io/build", "git@github.com:shipwright-io/build.git", true),
+	Entry("different case and trailing slash", "https://GitHub.com/Shipwright-io/Build/", "https://github.com/shipwright-io/build", true),
+	Entry("different repositories", "https://github.com/shipwright-io/build", "https://github.com/shipwright-io/cli", false),
+	Entry("different hosts", "https://github.com/shipwright-io/build", "https://gitlab.com/shipwright-io/build", false),
+	Entry("URL without repository", "https://github.com/shipwright-io", "https://github.com/shipwright-io", false),
+)
+
+var _ = Describe("DefaultAPIURL", func() {
+	It("returns the API of the host", func() {
+		Expect(scm.DefaultAPIURL(build.GitHubProvider, &scm.Repository{Host: "github.com"})).To(Equal("https://api.github.com"))
+		Expect(scm.DefaultAPIURL(build.GitHubProvider, &scm.Repository{Host: "github.example.com"})).To(Equal("https://github.example.com/api/v3"))
+		Expect(scm.DefaultAPIURL(build.GitLabProvider, &scm.Repository{Host: "gitlab.com"})).To(Equal("https://gitlab.com/api/v4"))
+		Expect(scm.DefaultAPIURL(build.GiteaProvider, &scm.Repository{Host: "gitea.example.com"})).To(Equal("https://gitea.example.com/api/v1"))
+		Expect(scm.DefaultAPIURL(build.BitbucketProvider, &scm.Repository{Host: "bitbucket.org"})).To(Equal("https://api.bitbucket.org/2.0"))
+	})
+})
+
+var _ = Describe("APIURL", func() {
+	var repository = &scm.Repository{Host: "github.com", Path: "shipwright-io/sample-go"}
+
+	It("returns the default API URL if the status report does not define one", func() {
+		Expect(scm.APIURL(build.GitHubProvider, repository, nil, nil)).To(Equal("https://api.github.com"))
+	})
+
+	It("accepts API URLs on the host of the repository or of the default API URL", func() {
+		Expect(scm.APIURL(build.GitHubProvider, repository, ptr.To("https://github.com/api/v3"), nil)).To(Equal("https://github.com/api/v3"))
+		Expect(scm.APIURL(build.GitHubProvider, repository, ptr.To("https://API.github.com/"), nil)).To(Equal("https://API.github.com/"))
+	})
+
+	It("accepts API URLs on allowed hosts", func() {
+		Expect(scm.APIURL(build.GitHubProvider, repository, ptr.To("https://git-api.example.com"), []string{"git-api.example.com"})).To(Equal("https://git-api.example.com"))
+		Expect(scm.APIURL(build.GitHubProvider, repository, ptr.To("https://git-api.example.com:8443"), []string{"git-api.example.com:8443"})).To(Equal("https://git-api.example.com:8443"))
+	})
+
+	It("rejects API URLs on other hosts", func() {
+		_, err := scm.APIURL(build.GitHubProvider, repository, ptr.To("https://attacker.example.com"), []string{"git-api.example.com"})
+		Expect(err).To(MatchError(ContainSubstring("neither the host of the repository nor an allowed API host")))
+
+		_, err = scm.APIURL(build.GitHubProvider, repository, ptr.To("https://git-api.example.com:9443"), []string{"git-api.example.com:8443"})
+		Expect(err).To(HaveOccurred())
+
+		_, err = scm.APIURL(build.GitHubProvider, repository, ptr.To("https://github.com.attacker.example.com"), nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects API URLs that do not use HTTP", func() {
+		_, err := scm.APIURL(build.GitHubProvider, repository, ptr.To("file://github.com/etc/passwd"), nil)
+		Expect(err).To(MatchError(ContainSubstring("does not use HTTP or HTTPS")))
+	})
+})
+
+var _ = Describe("StatusClient", func() {
+	var (
+		server     *httptest.Server
+		repository *scm.Repository
+		request    *http.Request
+		payload    map[string]string
+		statusCode int
+	)
+
+	BeforeEach(func() {
+		request = nil
+		payload = nil
+		statusCode = http.StatusCreated
+		repository = &scm.Repository{Host: "git.example.com", Path: "shipwright-io/sample-go"}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			if statusCode == http.StatusTemporaryRedirect {
+				w.Header().Set("Location", "/elsewhere")
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			w.WriteHeader(statusCode)
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	setStatus := func(provider build.GitProvider, state scm.State) error {
+		client, err := scm.NewStatusClient(provider, server.URL+"/", "secret-token")
+		Expect(err).ToNot(HaveOccurred())
+
+		return client.SetStatus(context.TODO(), repository, sha, scm.Status{
+			State:       state,
+			Context:     "shipwright/sample-go",
+			Description: "The BuildRun sample-go-abcde succeeded",
+		})
+	}
+
+	It("reports a GitHub commit status", func() {
+		Expect(setStatus(build.GitHubProvider, scm.StateSuccess)).To(Succeed())
+		Expect(request.Method).To(Equal(http.MethodPost))
+		Expect(request.URL.Path).To(Equal("/repos/shipwright-io/sample-go/statuses/" + sha))
+		Expect(request.Header.Get("Authorization")).To(Equal("Bearer secret-token"))
+		Expect(payload).To(HaveKeyWithValue("state", "success"))
+		Expect(payload).To(HaveKeyWithValue("context", "shipwright/sample-go"))
+	})
+
+	It("reports a GitLab commit status", func() {
+		repository.Path = "group/subgroup/project"
+
+		Expect(setStatus(build.GitLabProvider, scm.StateFailure)).To(Succeed())
+		Expect(request.URL.EscapedPath()).To(Equal("/projects/group%2Fsubgroup%2Fproject/statuses/" + sha))
+		Expect(request.Header.Get("Private-Token")).To(Equal("secret-token"))
+		Expect(payload).To(HaveKeyWithValue("state", "failed"))
+		Expect(payload).To(HaveKeyWithValue("name", "shipwright/sample-go"))
+	})
+
+	It("reports a Gitea commit status", func() {
+		Expect(setStatus(build.GiteaProvider, scm.StatePending)).To(Succeed())
+		Expect(request.URL.Path).To(Equal("/repos/shipwright-io/sample-go/statuses/" + sha))
+		Expect(request.Header.Get("Authorization")).To(Equal("token secret-token"))
+		Expect(payload).To(HaveKeyWithValue("state", "pending"))
+	})
+
+	It("reports a Bitbucket build status", func() {
+		Expect(setStatus(build.BitbucketProvider, scm.StatePending)).To(Succeed())
+		Expect(request.URL.Path).To(Equal("/repositories/shipwright-io/sample-go/commit/" + sha + "/statuses/build"))
+		Expect(payload).To(HaveKeyWithValue("state", "INPROGRESS"))
+		Expect(payload).To(HaveKeyWithValue("key", "shipwright/sample-go"))
+		Expect(payload).To(HaveKeyWithValue("url", "https://git.example.com/shipwright-io/sample-go"))
+	})
+
+	It("fails when the API rejects the request", func() {
+		statusCode = http.StatusUnauthorized
+		Expect(setStatus(build.GitHubProvider, scm.StateSuccess)).To(MatchError(ContainSubstring("failed with status 401")))
+	})
+
+	It("does not follow redirects", func() {
+		statusCode = http.StatusTemporaryRedirect
+		Expect(setStatus(build.GitHubProvider, scm.StateSuccess)).To(MatchError(ContainSubstring("failed with status 307")))
+		Expect(request.URL.Path).To(Equal("/repos/shipwright-io/sample-go/statuses/" + sha))
+	})
+
+	It("fails for unsupported providers", func() {
+		_, err := scm.NewStatusClient("Subversion", server.URL, "secret-token")
+		Expect(err).To(HaveOccurred())
+	})
+})
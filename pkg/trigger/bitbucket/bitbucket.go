@@ -0,0 +1,171 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	eventHeader     = "X-Event-Key"
+	signatureHeader = "X-Hub-Signature"
+	signaturePrefix = "sha256="
+
+	repoPushEvent          = "repo:push"
+	pullRequestCreateEvent = "pullrequest:created"
+	pullRequestUpdateEvent = "pullrequest:updated"
+
+	branchChange = "branch"
+	tagChange    = "tag"
+)
+
+type repository struct {
+	FullName string `json:"full_name"`
+	Links    struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// urls returns the web URL of the repository and the SSH URL derived from it, the HTTPS clone
+// URL only differs from the web URL by the .git suffix
+func (r repository) urls() []string {
+	urls := []string{r.Links.HTML.Href}
+	if webURL, err := url.Parse(r.Links.HTML.Href); err == nil && webURL.Host != "" && r.FullName != "" {
+		urls = append(urls, fmt.Sprintf("git@%s:%s.git", webURL.Hostname(), r.FullName))
+	}
+	return urls
+}
+
+type reference struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+type pushPayload struct {
+	Push struct {
+		Changes []struct {
+			New *reference `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	Repository repository `json:"repository"`
+}
+
+type pullRequestPayload struct {
+	PullRequest struct {
+		Source struct {
+			Commit struct {
+				Hash string `json:"hash"`
+			} `json:"commit"`
+			Repository *repository `json:"repository"`
+		} `json:"source"`
+		Destination struct {
+			Branch struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+			Repository *repository `json:"repository"`
+		} `json:"destination"`
+	} `json:"pullrequest"`
+	Repository repository `json:"repository"`
+}
+
+// Provider handles webhook requests sent by Bitbucket Cloud
+type Provider struct{}
+
+var _ trigger.Provider = Provider{}
+
+// Type returns the Bitbucket trigger type
+func (Provider) Type() build.TriggerType {
+	return build.BitbucketWebHookTrigger
+}
+
+// Detect returns true when the request carries the Bitbucket event header
+func (Provider) Detect(header http.Header) bool {
+	return header.Get(eventHeader) != ""
+}
+
+// Parse extracts the event from repository push and pull-request payloads, pushes of tags are tag
+// events, other Bitbucket events are ignored
+func (Provider) Parse(header http.Header, body []byte) (*trigger.Event, error) {
+	switch header.Get(eventHeader) {
+	case repoPushEvent:
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse Bitbucket push event: %w", err)
+		}
+
+		// a push can update several references, the first created or updated one is used,
+		// deleted branches and tags have no new reference
+		for _, change := range payload.Push.Changes {
+			if change.New == nil {
+				continue
+			}
+
+			switch change.New.Type {
+			case branchChange:
+				return &trigger.Event{
+					Type:           build.BitbucketWebHookTrigger,
+					Name:           string(build.BitbucketPushEvent),
+					RepositoryURLs: payload.Repository.urls(),
+					Branch:         change.New.Name,
+					Revision:       change.New.Target.Hash,
+				}, nil
+
+			case tagChange:
+				return &trigger.Event{
+					Type:           build.BitbucketWebHookTrigger,
+					Name:           string(build.BitbucketTagEvent),
+					RepositoryURLs: payload.Repository.urls(),
+					Tag:            change.New.Name,
+					Revision:       change.New.Target.Hash,
+				}, nil
+			}
+		}
+
+		return nil, nil
+
+	case pullRequestCreateEvent, pullRequestUpdateEvent:
+		var payload pullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse Bitbucket pull-request event: %w", err)
+		}
+
+		// the source repository of a pull-request from a deleted fork is null
+		source, destination := payload.PullRequest.Source.Repository, payload.PullRequest.Destination.Repository
+		return &trigger.Event{
+			Type:           build.BitbucketWebHookTrigger,
+			Name:           string(build.BitbucketPullRequestEvent),
+			RepositoryURLs: payload.Repository.urls(),
+			Branch:         payload.PullRequest.Destination.Branch.Name,
+			Revision:       payload.PullRequest.Source.Commit.Hash,
+			FromFork:       source == nil || destination == nil || !strings.EqualFold(source.FullName, destination.FullName),
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Verify checks the HMAC SHA-256 signature Bitbucket computes over the payload using the webhook secret
+func (Provider) Verify(header http.Header, body []byte, token []byte) error {
+	signature := header.Get(signatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.New("request is not signed")
+	}
+
+	return trigger.VerifyHMACSHA256(strings.TrimPrefix(signature, signaturePrefix), body, token)
+}
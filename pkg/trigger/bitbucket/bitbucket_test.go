@@ -0,0 +1,153 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bitbucket_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger/bitbucket"
+)
+
+const repository = `"repository": {
+    "full_name": "shipwright-io/sample-go",
+    "links": {"html": {"href": "https://bitbucket.org/shipwright-io/sample-go"}}
+  }`
+
+const pushPayload = `{
+  "push": {
+    "changes": [{
+      "new": {"type": "branch", "name": "main", "target": {"hash": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"}}
+    }]
+  },
+  ` + repository + `
+}`
+
+const tagPushPayload = `{
+  "push": {
+    "changes": [{
+      "new": {"type": "tag", "name": "v1.2.3", "target": {"hash": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7"}}
+    }]
+  },
+  ` + repository + `
+}`
+
+const pullRequestPayload = `{
+  "pullrequest": {
+    "source": {"commit": {"hash": "9e8d7c6b5a4f"}, "repository": {"full_name": "shipwright-io/sample-go"}},
+    "destination": {"branch": {"name": "main"}, "repository": {"full_name": "shipwright-io/sample-go"}}
+  },
+  ` + repository + `
+}`
+
+func header(event string) http.Header {
+	h := http.Header{}
+	h.Set("X-Event-Key", event)
+	return h
+}
+
+func sign(body, token string) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("Bitbucket provider", func() {
+	provider := bitbucket.Provider{}
+
+	It("detects Bitbucket requests", func() {
+		Expect(provider.Detect(header("repo:push"))).To(BeTrue())
+		Expect(provider.Detect(http.Header{})).To(BeFalse())
+	})
+
+	Context("parsing events", func() {
+		It("parses a branch push", func() {
+			event, err := provider.Parse(header("repo:push"), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Type).To(Equal(build.BitbucketWebHookTrigger))
+			Expect(event.Name).To(Equal(string(build.BitbucketPushEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+			Expect(event.RepositoryURLs).To(ConsistOf(
+				"https://bitbucket.org/shipwright-io/sample-go",
+				"git@bitbucket.org:shipwright-io/sample-go.git",
+			))
+		})
+
+		It("parses a tag push", func() {
+			event, err := provider.Parse(header("repo:push"), []byte(tagPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.BitbucketTagEvent)))
+			Expect(event.Tag).To(Equal("v1.2.3"))
+			Expect(event.Revision).To(Equal("82b3d5ae55f7080f1e6022629cdb57bfae7cccc7"))
+		})
+
+		It("parses a pull-request update", func() {
+			event, err := provider.Parse(header("pullrequest:updated"), []byte(pullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.BitbucketPullRequestEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("9e8d7c6b5a4f"))
+			Expect(event.FromFork).To(BeFalse())
+		})
+
+		It("detects pull-requests from forks", func() {
+			payload := strings.Replace(pullRequestPayload, `"repository": {"full_name": "shipwright-io/sample-go"}},`, `"repository": {"full_name": "someone/sample-go"}},`, 1)
+			event, err := provider.Parse(header("pullrequest:updated"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+
+			payload = strings.Replace(pullRequestPayload, `"repository": {"full_name": "shipwright-io/sample-go"}},`, `"repository": null},`, 1)
+			event, err = provider.Parse(header("pullrequest:updated"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+		})
+
+		It("ignores branch deletions", func() {
+			event, err := provider.Parse(header("repo:push"), []byte(`{"push": {"changes": [{"new": null}]}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores unrelated events", func() {
+			event, err := provider.Parse(header("pullrequest:fulfilled"), []byte(`{}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("fails on an invalid payload", func() {
+			_, err := provider.Parse(header("repo:push"), []byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("verifying the signature", func() {
+		It("accepts a valid signature", func() {
+			h := header("repo:push")
+			h.Set("X-Hub-Signature", sign(pushPayload, "s3cr3t"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("rejects a signature made with another token", func() {
+			h := header("repo:push")
+			h.Set("X-Hub-Signature", sign(pushPayload, "other"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+
+		It("rejects an unsigned request", func() {
+			Expect(provider.Verify(header("repo:push"), []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,122 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// maxTagLength is the maximum length of an image tag
+const maxTagLength = 128
+
+// maxNameLength is the maximum length of a BuildRun name, which is also used as label value
+const maxNameLength = 63
+
+// nameHashLength is the length of the hash that makes a BuildRun name unique
+const nameHashLength = 10
+
+// invalidTagCharacters matches the characters that are not allowed in image tags
+var invalidTagCharacters = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// NewBuildRun returns a BuildRun for the Build that was triggered by the event. The BuildRun
+// references the Build by name and, when the event carries a commit SHA or tag, pins the Git revision
+// to it. Tag events can override the tag of the output image with the Git tag name. When the event
+// was caused by a Kubernetes object, the BuildRun is labeled and annotated with it.
+func NewBuildRun(b *build.Build, when *build.TriggerWhen, event *Event) *build.BuildRun {
+	buildRun := &build.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: b.Name + "-",
+			Namespace:    b.Namespace,
+			Labels: map[string]string{
+				build.LabelBuild: b.Name,
+			},
+			Annotations: map[string]string{
+				build.AnnotationTriggerName: when.Name,
+				build.AnnotationTriggerType: string(when.Type),
+			},
+		},
+		Spec: build.BuildRunSpec{
+			Build: build.ReferencedBuild{
+				Name: ptr.To(b.Name),
+			},
+		},
+	}
+
+	// tag events without a commit SHA, like releases, are pinned to the tag instead
+	revision := event.Revision
+	if revision == "" {
+		revision = event.Tag
+	}
+
+	if revision != "" {
+		buildRun.Spec.Source = &build.BuildRunSource{
+			Type: build.GitType,
+			Git: &build.BuildRunGit{
+				Revision: ptr.To(revision),
+			},
+		}
+	}
+
+	if event.Tag != "" && when.TagOutputImage != nil && *when.TagOutputImage {
+		if image, ok := tagImage(b.Spec.Output.Image, event.Tag); ok {
+			buildRun.Spec.Output = &build.Image{Image: image}
+		}
+	}
+
+	if event.Object != nil {
+		buildRun.Labels[build.LabelTriggerObjectUID] = string(event.Object.UID)
+		buildRun.Annotations[build.AnnotationTriggerObjectKind] = event.Object.Kind
+		buildRun.Annotations[build.AnnotationTriggerObjectName] = event.Object.Name
+	}
+
+	return buildRun
+}
+
+// BuildRunName returns a deterministic name for the BuildRun of the Build that is created for the key,
+// for example an activation of a schedule. A second attempt to create the BuildRun for the same key
+// then fails with an AlreadyExists error instead of creating a duplicate.
+func BuildRunName(b *build.Build, key string) string {
+	hash := sha256.Sum256([]byte(b.Name + "/" + key))
+
+	prefix := b.Name
+	if len(prefix) > maxNameLength-nameHashLength-1 {
+		prefix = prefix[:maxNameLength-nameHashLength-1]
+	}
+
+	// a name part must end with an alphanumeric character
+	prefix = strings.TrimRight(prefix, "-.")
+
+	return prefix + "-" + hex.EncodeToString(hash[:])[:nameHashLength]
+}
+
+// tagImage replaces the tag or digest of the image with the Git tag name. Characters that are not
+// allowed in image tags are replaced, for example release/1.0 becomes release-1.0.
+func tagImage(image string, gitTag string) (string, bool) {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return "", false
+	}
+
+	tag := invalidTagCharacters.ReplaceAllString(gitTag, "-")
+	if len(tag) > maxTagLength {
+		tag = tag[:maxTagLength]
+	}
+
+	tagged, err := name.NewTag(ref.Context().Name()+":"+tag, name.WeakValidation)
+	if err != nil {
+		return "", false
+	}
+
+	return tagged.String(), true
+}
@@ -0,0 +1,96 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// Event is the provider independent representation of an event that triggers builds, for example
+// a Git service webhook event, it carries the attributes needed to find the Builds that should be
+// triggered by it.
+type Event struct {
+	// Type is the trigger type matching the provider that sent the event
+	Type build.TriggerType
+
+	// Name is the event name as used in the trigger conditions of the provider, for example Push
+	Name string
+
+	// RepositoryURLs are the different URLs (HTTPS, SSH, etc.) of the repository the event belongs to
+	RepositoryURLs []string
+
+	// Branch is the name of the branch the event applies to, for pull-requests this is the
+	// target (base) branch
+	Branch string
+
+	// Tag is the name of the tag the event applies to, tag events have no branch
+	Tag string
+
+	// Revision is the commit SHA the event refers to, the BuildRun will be pinned to it
+	Revision string
+
+	// FromFork is true for pull-requests whose changes are in a fork of the repository, they only
+	// trigger Builds that opted in, as the BuildRun builds code of the fork into the output image
+	FromFork bool
+
+	// ChangedFiles are the paths of the files changed by the event, relative to the repository
+	// root. It is nil when the payload of the event does not list the changed files.
+	ChangedFiles []string
+
+	// Object references the Kubernetes object that caused the event, the BuildRun will carry a
+	// reference back to it
+	Object *corev1.ObjectReference
+}
+
+// Commit is the part of a commit in a push event payload that lists the changed files, GitHub, GitLab
+// and Gitea all use this format.
+type Commit struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// ChangedFiles returns the distinct files changed by the commits, or nil when there are no commits
+func ChangedFiles(commits []Commit) []string {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	files := []string{}
+	seen := map[string]struct{}{}
+	for _, commit := range commits {
+		for _, list := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range list {
+				if _, ok := seen[file]; ok {
+					continue
+				}
+				seen[file] = struct{}{}
+				files = append(files, file)
+			}
+		}
+	}
+
+	return files
+}
+
+// Provider parses and authenticates webhook requests sent by a specific Git service.
+type Provider interface {
+	// Type returns the trigger type handled by the provider
+	Type() build.TriggerType
+
+	// Detect returns true when the request headers identify a request sent by the provider
+	Detect(header http.Header) bool
+
+	// Parse extracts the event from the request, a nil event without error means that the
+	// request carries an event that is not relevant for triggering builds
+	Parse(header http.Header, body []byte) (*Event, error)
+
+	// Verify checks the authenticity of the request using the token of the Build trigger secret
+	Verify(header http.Header, body []byte, token []byte) error
+}
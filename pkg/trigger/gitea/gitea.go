@@ -0,0 +1,155 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	eventHeader     = "X-Gitea-Event"
+	signatureHeader = "X-Gitea-Signature"
+
+	pushEvent        = "push"
+	pullRequestEvent = "pull_request"
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+
+	// zeroSHA is the commit SHA Gitea sends when a branch or tag was deleted
+	zeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// pullRequestActions are the pull-request actions that change the code of the pull-request
+var pullRequestActions = map[string]struct{}{
+	"opened":       {},
+	"reopened":     {},
+	"synchronized": {},
+}
+
+type repository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+func (r repository) urls() []string {
+	return []string{r.HTMLURL, r.CloneURL, r.SSHURL}
+}
+
+type pushPayload struct {
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Commits    []trigger.Commit `json:"commits"`
+	Repository repository       `json:"repository"`
+}
+
+type pullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Head struct {
+			SHA  string      `json:"sha"`
+			Repo *repository `json:"repo"`
+		} `json:"head"`
+		Base struct {
+			Ref  string      `json:"ref"`
+			Repo *repository `json:"repo"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository repository `json:"repository"`
+}
+
+// Provider handles webhook requests sent by Gitea
+type Provider struct{}
+
+var _ trigger.Provider = Provider{}
+
+// Type returns the Gitea trigger type
+func (Provider) Type() build.TriggerType {
+	return build.GiteaWebHookTrigger
+}
+
+// Detect returns true when the request carries the Gitea event header. Gitea also sends the GitHub
+// event header, this provider must therefore be consulted before the GitHub provider.
+func (Provider) Detect(header http.Header) bool {
+	return header.Get(eventHeader) != ""
+}
+
+// Parse extracts the event from push and pull_request payloads, pushes of tags are tag events,
+// other Gitea events are ignored
+func (Provider) Parse(header http.Header, body []byte) (*trigger.Event, error) {
+	switch header.Get(eventHeader) {
+	case pushEvent:
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse Gitea push event: %w", err)
+		}
+
+		// deleting a branch or tag does not trigger a build
+		if payload.After == "" || payload.After == zeroSHA {
+			return nil, nil
+		}
+
+		switch {
+		case strings.HasPrefix(payload.Ref, branchRefPrefix):
+			return &trigger.Event{
+				Type:           build.GiteaWebHookTrigger,
+				Name:           string(build.GiteaPushEvent),
+				RepositoryURLs: payload.Repository.urls(),
+				Branch:         strings.TrimPrefix(payload.Ref, branchRefPrefix),
+				Revision:       payload.After,
+				ChangedFiles:   trigger.ChangedFiles(payload.Commits),
+			}, nil
+
+		case strings.HasPrefix(payload.Ref, tagRefPrefix):
+			return &trigger.Event{
+				Type:           build.GiteaWebHookTrigger,
+				Name:           string(build.GiteaTagEvent),
+				RepositoryURLs: payload.Repository.urls(),
+				Tag:            strings.TrimPrefix(payload.Ref, tagRefPrefix),
+				Revision:       payload.After,
+			}, nil
+
+		default:
+			return nil, nil
+		}
+
+	case pullRequestEvent:
+		var payload pullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse Gitea pull_request event: %w", err)
+		}
+
+		if _, ok := pullRequestActions[payload.Action]; !ok {
+			return nil, nil
+		}
+
+		// the head repository of a pull-request from a deleted fork is null
+		head, base := payload.PullRequest.Head.Repo, payload.PullRequest.Base.Repo
+		return &trigger.Event{
+			Type:           build.GiteaWebHookTrigger,
+			Name:           string(build.GiteaPullRequestEvent),
+			RepositoryURLs: payload.Repository.urls(),
+			Branch:         payload.PullRequest.Base.Ref,
+			Revision:       payload.PullRequest.Head.SHA,
+			FromFork:       head == nil || base == nil || !strings.EqualFold(head.FullName, base.FullName),
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Verify checks the HMAC SHA-256 signature Gitea computes over the payload using the webhook secret
+func (Provider) Verify(header http.Header, body []byte, token []byte) error {
+	return trigger.VerifyHMACSHA256(header.Get(signatureHeader), body, token)
+}
@@ -0,0 +1,155 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitea_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger/gitea"
+)
+
+const repository = `"repository": {
+    "html_url": "https://gitea.example.com/shipwright-io/sample-go",
+    "clone_url": "https://gitea.example.com/shipwright-io/sample-go.git",
+    "ssh_url": "git@gitea.example.com:shipwright-io/sample-go.git"
+  }`
+
+const pushPayload = `{
+  "ref": "refs/heads/main",
+  "after": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "commits": [
+    {"added": ["services/api/handler.go"], "removed": [], "modified": ["services/api/main.go"]},
+    {"added": [], "removed": ["docs/old.md"], "modified": ["services/api/main.go"]}
+  ],
+  ` + repository + `
+}`
+
+const tagPushPayload = `{
+  "ref": "refs/tags/v1.2.3",
+  "after": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7",
+  ` + repository + `
+}`
+
+const pullRequestPayload = `{
+  "action": "synchronized",
+  "pull_request": {
+    "head": {"sha": "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d", "repo": {"full_name": "shipwright-io/sample-go"}},
+    "base": {"ref": "main", "repo": {"full_name": "shipwright-io/sample-go"}}
+  },
+  ` + repository + `
+}`
+
+func header(event string) http.Header {
+	h := http.Header{}
+	h.Set("X-Gitea-Event", event)
+	h.Set("X-GitHub-Event", event)
+	return h
+}
+
+func sign(body, token string) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("Gitea provider", func() {
+	provider := gitea.Provider{}
+
+	It("detects Gitea requests", func() {
+		Expect(provider.Detect(header("push"))).To(BeTrue())
+
+		githubHeader := http.Header{}
+		githubHeader.Set("X-GitHub-Event", "push")
+		Expect(provider.Detect(githubHeader)).To(BeFalse())
+	})
+
+	Context("parsing events", func() {
+		It("parses a branch push", func() {
+			event, err := provider.Parse(header("push"), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Type).To(Equal(build.GiteaWebHookTrigger))
+			Expect(event.Name).To(Equal(string(build.GiteaPushEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+			Expect(event.ChangedFiles).To(Equal([]string{"services/api/handler.go", "services/api/main.go", "docs/old.md"}))
+			Expect(event.RepositoryURLs).To(ContainElement("git@gitea.example.com:shipwright-io/sample-go.git"))
+		})
+
+		It("parses a tag push", func() {
+			event, err := provider.Parse(header("push"), []byte(tagPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GiteaTagEvent)))
+			Expect(event.Tag).To(Equal("v1.2.3"))
+			Expect(event.Revision).To(Equal("82b3d5ae55f7080f1e6022629cdb57bfae7cccc7"))
+		})
+
+		It("parses a pull-request update", func() {
+			event, err := provider.Parse(header("pull_request"), []byte(pullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GiteaPullRequestEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+			Expect(event.FromFork).To(BeFalse())
+		})
+
+		It("detects pull-requests from forks", func() {
+			payload := strings.Replace(pullRequestPayload, `"repo": {"full_name": "shipwright-io/sample-go"}}`, `"repo": {"full_name": "someone/sample-go"}}`, 1)
+			event, err := provider.Parse(header("pull_request"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+
+			payload = strings.Replace(pullRequestPayload, `"repo": {"full_name": "shipwright-io/sample-go"}}`, `"repo": null}`, 1)
+			event, err = provider.Parse(header("pull_request"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+		})
+
+		It("ignores closed pull-requests", func() {
+			event, err := provider.Parse(header("pull_request"), []byte(`{"action": "closed"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores branch deletions", func() {
+			event, err := provider.Parse(header("push"), []byte(`{"ref": "refs/heads/main", "after": "0000000000000000000000000000000000000000"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("fails on an invalid payload", func() {
+			_, err := provider.Parse(header("push"), []byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("verifying the signature", func() {
+		It("accepts a valid signature", func() {
+			h := header("push")
+			h.Set("X-Gitea-Signature", sign(pushPayload, "s3cr3t"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("rejects a signature made with another token", func() {
+			h := header("push")
+			h.Set("X-Gitea-Signature", sign(pushPayload, "other"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+
+		It("rejects an unsigned request", func() {
+			Expect(provider.Verify(header("push"), []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,188 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	eventHeader     = "X-GitHub-Event"
+	signatureHeader = "X-Hub-Signature-256"
+	signaturePrefix = "sha256="
+
+	pushEvent        = "push"
+	pullRequestEvent = "pull_request"
+	releaseEvent     = "release"
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+
+	// releasePublishedAction is the release action when a release becomes visible, drafts are not built
+	releasePublishedAction = "published"
+)
+
+// pullRequestActions are the pull-request actions that change the code of the pull-request
+var pullRequestActions = map[string]struct{}{
+	"opened":      {},
+	"reopened":    {},
+	"synchronize": {},
+}
+
+type repository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+	CloneURL string `json:"clone_url"`
+	SSHURL   string `json:"ssh_url"`
+	GitURL   string `json:"git_url"`
+}
+
+func (r repository) urls() []string {
+	return []string{r.HTMLURL, r.CloneURL, r.SSHURL, r.GitURL}
+}
+
+type pushPayload struct {
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Commits    []trigger.Commit `json:"commits"`
+	Repository repository       `json:"repository"`
+}
+
+type pullRequestPayload struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Head struct {
+			SHA  string      `json:"sha"`
+			Repo *repository `json:"repo"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository repository `json:"repository"`
+}
+
+type releasePayload struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName string `json:"tag_name"`
+	} `json:"release"`
+	Repository repository `json:"repository"`
+}
+
+// Provider handles webhook requests sent by GitHub
+type Provider struct{}
+
+var _ trigger.Provider = Provider{}
+
+// Type returns the GitHub trigger type
+func (Provider) Type() build.TriggerType {
+	return build.GitHubWebHookTrigger
+}
+
+// Detect returns true when the request carries the GitHub event header
+func (Provider) Detect(header http.Header) bool {
+	return header.Get(eventHeader) != ""
+}
+
+// Parse extracts the event from push, pull_request and release payloads, other GitHub events are ignored
+func (Provider) Parse(header http.Header, body []byte) (*trigger.Event, error) {
+	switch header.Get(eventHeader) {
+	case pushEvent:
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub push event: %w", err)
+		}
+
+		// deleting a branch or tag does not trigger a build
+		if payload.Deleted {
+			return nil, nil
+		}
+
+		switch {
+		case strings.HasPrefix(payload.Ref, branchRefPrefix):
+			return &trigger.Event{
+				Type:           build.GitHubWebHookTrigger,
+				Name:           string(build.GitHubPushEvent),
+				RepositoryURLs: payload.Repository.urls(),
+				Branch:         strings.TrimPrefix(payload.Ref, branchRefPrefix),
+				Revision:       payload.After,
+				ChangedFiles:   trigger.ChangedFiles(payload.Commits),
+			}, nil
+
+		case strings.HasPrefix(payload.Ref, tagRefPrefix):
+			return &trigger.Event{
+				Type:           build.GitHubWebHookTrigger,
+				Name:           string(build.GitHubTagEvent),
+				RepositoryURLs: payload.Repository.urls(),
+				Tag:            strings.TrimPrefix(payload.Ref, tagRefPrefix),
+				Revision:       payload.After,
+			}, nil
+
+		default:
+			return nil, nil
+		}
+
+	case pullRequestEvent:
+		var payload pullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub pull_request event: %w", err)
+		}
+
+		if _, ok := pullRequestActions[payload.Action]; !ok {
+			return nil, nil
+		}
+
+		return &trigger.Event{
+			Type:           build.GitHubWebHookTrigger,
+			Name:           string(build.GitHubPullRequestEvent),
+			RepositoryURLs: payload.Repository.urls(),
+			Branch:         payload.PullRequest.Base.Ref,
+			Revision:       payload.PullRequest.Head.SHA,
+			// the head repository of a pull-request from a deleted fork is null
+			FromFork: payload.PullRequest.Head.Repo == nil || !strings.EqualFold(payload.PullRequest.Head.Repo.FullName, payload.Repository.FullName),
+		}, nil
+
+	case releaseEvent:
+		var payload releasePayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitHub release event: %w", err)
+		}
+
+		if payload.Action != releasePublishedAction || payload.Release.TagName == "" {
+			return nil, nil
+		}
+
+		// the release payload carries no commit SHA, the BuildRun is pinned to the tag
+		return &trigger.Event{
+			Type:           build.GitHubWebHookTrigger,
+			Name:           string(build.GitHubReleaseEvent),
+			RepositoryURLs: payload.Repository.urls(),
+			Tag:            payload.Release.TagName,
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Verify checks the HMAC SHA-256 signature GitHub computes over the payload using the webhook secret
+func (Provider) Verify(header http.Header, body []byte, token []byte) error {
+	signature := header.Get(signatureHeader)
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.New("request is not signed")
+	}
+
+	return trigger.VerifyHMACSHA256(strings.TrimPrefix(signature, signaturePrefix), body, token)
+}
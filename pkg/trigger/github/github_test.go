@@ -0,0 +1,180 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package github_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger/github"
+)
+
+const pushPayload = `{
+  "ref": "refs/heads/main",
+  "after": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "deleted": false,
+  "commits": [
+    {"added": ["services/api/handler.go"], "removed": [], "modified": ["services/api/main.go"]},
+    {"added": [], "removed": ["docs/old.md"], "modified": ["services/api/main.go"]}
+  ],
+  "repository": {
+    "html_url": "https://github.com/shipwright-io/sample-go",
+    "clone_url": "https://github.com/shipwright-io/sample-go.git",
+    "ssh_url": "git@github.com:shipwright-io/sample-go.git",
+    "git_url": "git://github.com/shipwright-io/sample-go.git"
+  }
+}`
+
+const pullRequestPayload = `{
+  "action": "synchronize",
+  "number": 42,
+  "pull_request": {
+    "head": {"sha": "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d", "ref": "feature", "repo": {"full_name": "shipwright-io/sample-go"}},
+    "base": {"ref": "main"}
+  },
+  "repository": {
+    "full_name": "shipwright-io/sample-go",
+    "html_url": "https://github.com/shipwright-io/sample-go",
+    "clone_url": "https://github.com/shipwright-io/sample-go.git"
+  }
+}`
+
+func header(event string) http.Header {
+	h := http.Header{}
+	h.Set("X-GitHub-Event", event)
+	return h
+}
+
+func sign(body, token string) string {
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("GitHub provider", func() {
+	provider := github.Provider{}
+
+	It("detects GitHub requests", func() {
+		Expect(provider.Detect(header("push"))).To(BeTrue())
+		Expect(provider.Detect(http.Header{})).To(BeFalse())
+	})
+
+	Context("parsing events", func() {
+		It("parses a branch push", func() {
+			event, err := provider.Parse(header("push"), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Type).To(Equal(build.GitHubWebHookTrigger))
+			Expect(event.Name).To(Equal(string(build.GitHubPushEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+			Expect(event.ChangedFiles).To(Equal([]string{"services/api/handler.go", "services/api/main.go", "docs/old.md"}))
+			Expect(event.RepositoryURLs).To(ContainElement("git@github.com:shipwright-io/sample-go.git"))
+		})
+
+		It("parses a pull-request update", func() {
+			event, err := provider.Parse(header("pull_request"), []byte(pullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GitHubPullRequestEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+			Expect(event.FromFork).To(BeFalse())
+		})
+
+		It("detects pull-requests from forks", func() {
+			payload := strings.Replace(pullRequestPayload, `"repo": {"full_name": "shipwright-io/sample-go"}`, `"repo": {"full_name": "someone/sample-go"}`, 1)
+			event, err := provider.Parse(header("pull_request"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+
+			payload = strings.Replace(pullRequestPayload, `"repo": {"full_name": "shipwright-io/sample-go"}`, `"repo": null`, 1)
+			event, err = provider.Parse(header("pull_request"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+		})
+
+		It("ignores closed pull-requests", func() {
+			event, err := provider.Parse(header("pull_request"), []byte(`{"action": "closed"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores branch deletions", func() {
+			event, err := provider.Parse(header("push"), []byte(`{"ref": "refs/heads/main", "deleted": true}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("parses a tag push", func() {
+			event, err := provider.Parse(header("push"), []byte(`{
+  "ref": "refs/tags/v1.4.2",
+  "after": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "repository": {"clone_url": "https://github.com/shipwright-io/sample-go.git"}
+}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GitHubTagEvent)))
+			Expect(event.Tag).To(Equal("v1.4.2"))
+			Expect(event.Branch).To(BeEmpty())
+			Expect(event.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+		})
+
+		It("parses a published release", func() {
+			event, err := provider.Parse(header("release"), []byte(`{
+  "action": "published",
+  "release": {"tag_name": "v1.4.2", "draft": false},
+  "repository": {"clone_url": "https://github.com/shipwright-io/sample-go.git"}
+}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GitHubReleaseEvent)))
+			Expect(event.Tag).To(Equal("v1.4.2"))
+			Expect(event.Revision).To(BeEmpty())
+		})
+
+		It("ignores releases that are not published", func() {
+			event, err := provider.Parse(header("release"), []byte(`{"action": "created", "release": {"tag_name": "v1.4.2"}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores unrelated events", func() {
+			event, err := provider.Parse(header("ping"), []byte(`{"zen": "Keep it logically awesome."}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("fails on an invalid payload", func() {
+			_, err := provider.Parse(header("push"), []byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("verifying the signature", func() {
+		It("accepts a valid signature", func() {
+			h := header("push")
+			h.Set("X-Hub-Signature-256", sign(pushPayload, "s3cr3t"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("rejects a signature made with another token", func() {
+			h := header("push")
+			h.Set("X-Hub-Signature-256", sign(pushPayload, "other"))
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+
+		It("rejects an unsigned request", func() {
+			Expect(provider.Verify(header("push"), []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+	})
+})
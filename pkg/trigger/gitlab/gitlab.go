@@ -0,0 +1,162 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	eventHeader = "X-Gitlab-Event"
+	tokenHeader = "X-Gitlab-Token"
+
+	pushHook         = "Push Hook"
+	tagPushHook      = "Tag Push Hook"
+	mergeRequestHook = "Merge Request Hook"
+
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+
+	// zeroSHA is the commit SHA GitLab sends when a branch or tag was deleted
+	zeroSHA = "0000000000000000000000000000000000000000"
+)
+
+// mergeRequestActions are the merge-request actions that can change the code of the merge-request,
+// an update only changes the code when it carries the previous revision
+var mergeRequestActions = map[string]struct{}{
+	"open":   {},
+	"reopen": {},
+	"update": {},
+}
+
+type project struct {
+	WebURL     string `json:"web_url"`
+	GitSSHURL  string `json:"git_ssh_url"`
+	GitHTTPURL string `json:"git_http_url"`
+}
+
+func (p project) urls() []string {
+	return []string{p.WebURL, p.GitSSHURL, p.GitHTTPURL}
+}
+
+type pushPayload struct {
+	Ref         string           `json:"ref"`
+	After       string           `json:"after"`
+	CheckoutSHA string           `json:"checkout_sha"`
+	Commits     []trigger.Commit `json:"commits"`
+	Project     project          `json:"project"`
+}
+
+type mergeRequestPayload struct {
+	Project          project `json:"project"`
+	ObjectAttributes struct {
+		Action          string `json:"action"`
+		TargetBranch    string `json:"target_branch"`
+		SourceProjectID int64  `json:"source_project_id"`
+		TargetProjectID int64  `json:"target_project_id"`
+		OldRev          string `json:"oldrev"`
+		LastCommit      struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+}
+
+// Provider handles webhook requests sent by GitLab
+type Provider struct{}
+
+var _ trigger.Provider = Provider{}
+
+// Type returns the GitLab trigger type
+func (Provider) Type() build.TriggerType {
+	return build.GitLabWebHookTrigger
+}
+
+// Detect returns true when the request carries the GitLab event header
+func (Provider) Detect(header http.Header) bool {
+	return header.Get(eventHeader) != ""
+}
+
+// Parse extracts the event from push, tag push and merge request hooks, other GitLab events are ignored
+func (Provider) Parse(header http.Header, body []byte) (*trigger.Event, error) {
+	switch header.Get(eventHeader) {
+	case pushHook:
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitLab push hook: %w", err)
+		}
+
+		// deleting a branch does not trigger a build
+		if payload.After == zeroSHA || !strings.HasPrefix(payload.Ref, branchRefPrefix) {
+			return nil, nil
+		}
+
+		return &trigger.Event{
+			Type:           build.GitLabWebHookTrigger,
+			Name:           string(build.GitLabPushEvent),
+			RepositoryURLs: payload.Project.urls(),
+			Branch:         strings.TrimPrefix(payload.Ref, branchRefPrefix),
+			Revision:       payload.After,
+			ChangedFiles:   trigger.ChangedFiles(payload.Commits),
+		}, nil
+
+	case tagPushHook:
+		var payload pushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitLab tag push hook: %w", err)
+		}
+
+		// deleting a tag does not trigger a build, GitLab sends no checkout SHA in this case
+		if payload.CheckoutSHA == "" || payload.After == zeroSHA || !strings.HasPrefix(payload.Ref, tagRefPrefix) {
+			return nil, nil
+		}
+
+		return &trigger.Event{
+			Type:           build.GitLabWebHookTrigger,
+			Name:           string(build.GitLabTagPushEvent),
+			RepositoryURLs: payload.Project.urls(),
+			Tag:            strings.TrimPrefix(payload.Ref, tagRefPrefix),
+			Revision:       payload.CheckoutSHA,
+		}, nil
+
+	case mergeRequestHook:
+		var payload mergeRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to parse GitLab merge request hook: %w", err)
+		}
+
+		attributes := payload.ObjectAttributes
+		if _, ok := mergeRequestActions[attributes.Action]; !ok {
+			return nil, nil
+		}
+
+		// updates of the title, description, labels, etc. do not change the code
+		if attributes.Action == "update" && attributes.OldRev == "" {
+			return nil, nil
+		}
+
+		return &trigger.Event{
+			Type:           build.GitLabWebHookTrigger,
+			Name:           string(build.GitLabMergeRequestEvent),
+			RepositoryURLs: payload.Project.urls(),
+			Branch:         attributes.TargetBranch,
+			Revision:       attributes.LastCommit.ID,
+			FromFork:       attributes.SourceProjectID != attributes.TargetProjectID,
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// Verify checks the secret token GitLab sends unmodified with every request
+func (Provider) Verify(header http.Header, _ []byte, token []byte) error {
+	return trigger.VerifyToken(header.Get(tokenHeader), token)
+}
@@ -0,0 +1,158 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab_test
+
+import (
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger/gitlab"
+)
+
+const project = `"project": {
+    "web_url": "https://gitlab.example.com/shipwright-io/sample-go",
+    "git_ssh_url": "git@gitlab.example.com:shipwright-io/sample-go.git",
+    "git_http_url": "https://gitlab.example.com/shipwright-io/sample-go.git"
+  }`
+
+const pushPayload = `{
+  "object_kind": "push",
+  "ref": "refs/heads/main",
+  "after": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "checkout_sha": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "commits": [
+    {"added": ["services/api/handler.go"], "removed": [], "modified": ["services/api/main.go"]},
+    {"added": [], "removed": ["docs/old.md"], "modified": ["services/api/main.go"]}
+  ],
+  ` + project + `
+}`
+
+const tagPushPayload = `{
+  "object_kind": "tag_push",
+  "ref": "refs/tags/v1.2.3",
+  "after": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7",
+  "checkout_sha": "82b3d5ae55f7080f1e6022629cdb57bfae7cccc7",
+  ` + project + `
+}`
+
+const mergeRequestPayload = `{
+  "object_kind": "merge_request",
+  "object_attributes": {
+    "action": "update",
+    "target_branch": "main",
+    "source_project_id": 42,
+    "target_project_id": 42,
+    "oldrev": "1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b",
+    "last_commit": {"id": "9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"}
+  },
+  ` + project + `
+}`
+
+func header(event string) http.Header {
+	h := http.Header{}
+	h.Set("X-Gitlab-Event", event)
+	return h
+}
+
+var _ = Describe("GitLab provider", func() {
+	provider := gitlab.Provider{}
+
+	It("detects GitLab requests", func() {
+		Expect(provider.Detect(header("Push Hook"))).To(BeTrue())
+		Expect(provider.Detect(http.Header{})).To(BeFalse())
+	})
+
+	Context("parsing events", func() {
+		It("parses a branch push", func() {
+			event, err := provider.Parse(header("Push Hook"), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Type).To(Equal(build.GitLabWebHookTrigger))
+			Expect(event.Name).To(Equal(string(build.GitLabPushEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+			Expect(event.ChangedFiles).To(Equal([]string{"services/api/handler.go", "services/api/main.go", "docs/old.md"}))
+			Expect(event.RepositoryURLs).To(ContainElement("git@gitlab.example.com:shipwright-io/sample-go.git"))
+		})
+
+		It("parses a tag push", func() {
+			event, err := provider.Parse(header("Tag Push Hook"), []byte(tagPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GitLabTagPushEvent)))
+			Expect(event.Tag).To(Equal("v1.2.3"))
+			Expect(event.Branch).To(BeEmpty())
+			Expect(event.Revision).To(Equal("82b3d5ae55f7080f1e6022629cdb57bfae7cccc7"))
+		})
+
+		It("parses a merge request update with new commits", func() {
+			event, err := provider.Parse(header("Merge Request Hook"), []byte(mergeRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal(string(build.GitLabMergeRequestEvent)))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d"))
+			Expect(event.FromFork).To(BeFalse())
+		})
+
+		It("detects merge requests from forks", func() {
+			payload := strings.Replace(mergeRequestPayload, `"source_project_id": 42`, `"source_project_id": 73`, 1)
+			event, err := provider.Parse(header("Merge Request Hook"), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.FromFork).To(BeTrue())
+		})
+
+		It("ignores merge request updates that do not change the code", func() {
+			event, err := provider.Parse(header("Merge Request Hook"), []byte(`{"object_attributes": {"action": "update"}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores merged merge requests", func() {
+			event, err := provider.Parse(header("Merge Request Hook"), []byte(`{"object_attributes": {"action": "merge"}}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores branch deletions", func() {
+			event, err := provider.Parse(header("Push Hook"), []byte(`{"ref": "refs/heads/main", "after": "0000000000000000000000000000000000000000"}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("ignores unrelated events", func() {
+			event, err := provider.Parse(header("Issue Hook"), []byte(`{}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("fails on an invalid payload", func() {
+			_, err := provider.Parse(header("Push Hook"), []byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("verifying the token", func() {
+		It("accepts the secret token", func() {
+			h := header("Push Hook")
+			h.Set("X-Gitlab-Token", "s3cr3t")
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("rejects another token", func() {
+			h := header("Push Hook")
+			h.Set("X-Gitlab-Token", "other")
+			Expect(provider.Verify(h, []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+
+		It("rejects a request without a token", func() {
+			Expect(provider.Verify(header("Push Hook"), []byte(pushPayload), []byte("s3cr3t"))).ToNot(Succeed())
+		})
+	})
+})
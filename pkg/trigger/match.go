@@ -0,0 +1,224 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/scm"
+)
+
+// defaultBranch is the branch name used when neither the trigger nor the Git source name one
+const defaultBranch = "main"
+
+// Match returns the first trigger condition of the Build that matches the event, or nil if there
+// is none. The repository URL of the event must match the Git source of the Build, the event name
+// must be listed in the trigger condition, and the branch must match one of the trigger condition
+// branches. When the trigger condition has no branches, the Git source revision is used instead,
+// falling back to the default branch name. Tag events are matched regardless of the branches, the
+// tag must match one of the trigger condition tags instead, if there are any.
+// Pull-requests from forks only match trigger conditions that allow forks.
+// When the event lists the changed files, at least one of them must match the trigger condition
+// paths, or the context directory of the source, and must not match the ignored paths.
+func Match(b *build.Build, event *Event) *build.TriggerWhen {
+	if b.Spec.Trigger == nil || b.Spec.Source == nil || b.Spec.Source.Git == nil {
+		return nil
+	}
+
+	if !matchRepository(b.Spec.Source.Git.URL, event.RepositoryURLs) {
+		return nil
+	}
+
+	for i := range b.Spec.Trigger.When {
+		when := &b.Spec.Trigger.When[i]
+		if when.Type != event.Type {
+			continue
+		}
+
+		if !slices.Contains(when.GetEvents(when.Type), event.Name) {
+			continue
+		}
+
+		if event.FromFork && !when.AllowsForks(when.Type) {
+			continue
+		}
+
+		if !matchChangedFiles(b, when, event.ChangedFiles) {
+			continue
+		}
+
+		// tag events are not bound to a branch, but to the tags of the trigger condition
+		if event.Tag != "" {
+			if tags := when.GetTags(when.Type); len(tags) == 0 || matchName(event.Tag, tags) {
+				return when
+			}
+			continue
+		}
+
+		branches := when.GetBranches(when.Type)
+		if len(branches) == 0 {
+			if b.Spec.Source.Git.Revision != nil && *b.Spec.Source.Git.Revision != "" {
+				branches = []string{*b.Spec.Source.Git.Revision}
+			} else {
+				branches = []string{defaultBranch}
+			}
+		}
+
+		if matchName(event.Branch, branches) {
+			return when
+		}
+	}
+
+	return nil
+}
+
+// MatchObjectRef returns the first trigger condition of the given type that matches a Kubernetes
+// object, or nil if there is none. The object is identified by one of its names, or by its labels
+// when the trigger condition uses a label selector, and one of its statuses must be listed in the
+// trigger condition.
+func MatchObjectRef(b *build.Build, triggerType build.TriggerType, names []string, objectLabels map[string]string, statuses []string) *build.TriggerWhen {
+	if b.Spec.Trigger == nil {
+		return nil
+	}
+
+	for i := range b.Spec.Trigger.When {
+		when := &b.Spec.Trigger.When[i]
+		if when.Type != triggerType || when.ObjectRef == nil {
+			continue
+		}
+
+		switch {
+		case when.ObjectRef.Name != "":
+			if !slices.Contains(names, when.ObjectRef.Name) {
+				continue
+			}
+
+		case len(when.ObjectRef.Selector) > 0:
+			if !labels.SelectorFromSet(when.ObjectRef.Selector).Matches(labels.Set(objectLabels)) {
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		for _, status := range statuses {
+			if slices.Contains(when.ObjectRef.Status, status) {
+				return when
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchRepository checks whether the Git source URL points to the same repository as any of
+// the event repository URLs.
+func matchRepository(sourceURL string, eventURLs []string) bool {
+	for _, eventURL := range eventURLs {
+		if eventURL != "" && scm.SameRepository(sourceURL, eventURL) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchName checks whether the branch or tag name matches any of the names, which may contain glob patterns.
+func matchName(value string, names []string) bool {
+	for _, name := range names {
+		if name == value {
+			return true
+		}
+		if matched, err := path.Match(name, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchChangedFiles checks whether any of the changed files is neither ignored nor outside of the paths of
+// the trigger condition, which default to the context directory of the source. Unknown changes always match.
+func matchChangedFiles(b *build.Build, when *build.TriggerWhen, files []string) bool {
+	if files == nil {
+		return true
+	}
+
+	paths := when.GetPaths(when.Type)
+	if len(paths) == 0 && b.Spec.Source.ContextDir != nil {
+		if contextDir := path.Clean("/" + *b.Spec.Source.ContextDir); contextDir != "/" {
+			paths = []string{contextDir}
+		}
+	}
+
+	ignorePaths := when.GetIgnorePaths(when.Type)
+	if len(paths) == 0 && len(ignorePaths) == 0 {
+		return true
+	}
+
+	for _, file := range files {
+		if matchPath(file, ignorePaths) {
+			continue
+		}
+
+		if len(paths) == 0 || matchPath(file, paths) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPath checks whether the file matches any of the glob patterns. Patterns are matched per path
+// segment, "**" matches any number of segments, and a pattern matching a directory matches all files in it.
+func matchPath(file string, patterns []string) bool {
+	fileSegments := strings.Split(strings.Trim(file, "/"), "/")
+	for _, pattern := range patterns {
+		if matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), fileSegments) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern []string, file []string) bool {
+	if len(pattern) == 0 {
+		return true
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(file); i++ {
+			if matchSegments(pattern[1:], file[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(file) == 0 {
+		return false
+	}
+
+	if matched, err := path.Match(pattern[0], file[0]); err != nil || !matched {
+		return false
+	}
+
+	return matchSegments(pattern[1:], file[1:])
+}
+
+// ValidatePathPattern returns an error if the glob pattern of a trigger path is malformed
+func ValidatePathPattern(pattern string) error {
+	for _, segment := range strings.Split(strings.Trim(pattern, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,431 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+var _ = Describe("Match", func() {
+	var (
+		b     *build.Build
+		event *trigger.Event
+	)
+
+	BeforeEach(func() {
+		b = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-go",
+				Namespace: "default",
+			},
+			Spec: build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+					},
+				},
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name: "push on main",
+						Type: build.GitHubWebHookTrigger,
+						GitHub: &build.WhenGitHub{
+							Events: []build.GitHubEventName{build.GitHubPushEvent},
+						},
+					}},
+				},
+			},
+		}
+
+		event = &trigger.Event{
+			Type: build.GitHubWebHookTrigger,
+			Name: string(build.GitHubPushEvent),
+			RepositoryURLs: []string{
+				"https://github.com/shipwright-io/sample-go",
+				"https://github.com/shipwright-io/sample-go.git",
+				"git@github.com:shipwright-io/sample-go.git",
+			},
+			Branch:   "main",
+			Revision: "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+		}
+	})
+
+	It("matches the default branch when neither branches nor revision are set", func() {
+		when := trigger.Match(b, event)
+		Expect(when).ToNot(BeNil())
+		Expect(when.Name).To(Equal("push on main"))
+	})
+
+	It("does not match a Build without triggers", func() {
+		b.Spec.Trigger = nil
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	It("does not match a different repository", func() {
+		event.RepositoryURLs = []string{"https://github.com/shipwright-io/build"}
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	It("matches the repository using its SSH URL", func() {
+		b.Spec.Source.Git.URL = "git@github.com:Shipwright-IO/sample-go.git"
+		event.RepositoryURLs = []string{"https://github.com/shipwright-io/sample-go"}
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+	})
+
+	It("does not match an event that is not listed", func() {
+		event.Name = string(build.GitHubPullRequestEvent)
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	It("matches pull-requests from forks only when the trigger condition allows forks", func() {
+		b.Spec.Trigger.When[0].GitHub.Events = []build.GitHubEventName{build.GitHubPullRequestEvent}
+		event.Name = string(build.GitHubPullRequestEvent)
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+		event.FromFork = true
+		Expect(trigger.Match(b, event)).To(BeNil())
+
+		b.Spec.Trigger.When[0].GitHub.AllowForks = ptr.To(true)
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+	})
+
+	It("matches merge-requests from GitLab forks only when the trigger condition allows forks", func() {
+		b.Spec.Trigger.When[0] = build.TriggerWhen{
+			Name:   "gitlab",
+			Type:   build.GitLabWebHookTrigger,
+			GitLab: &build.WhenGitLab{Events: []build.GitLabEventName{build.GitLabMergeRequestEvent}},
+		}
+		event.Type = build.GitLabWebHookTrigger
+		event.Name = string(build.GitLabMergeRequestEvent)
+		event.FromFork = true
+		Expect(trigger.Match(b, event)).To(BeNil())
+
+		b.Spec.Trigger.When[0].GitLab.AllowForks = ptr.To(true)
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+	})
+
+	It("does not match a trigger of a different type", func() {
+		event.Type = build.ImageTrigger
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	It("uses the source revision when no branches are listed", func() {
+		b.Spec.Source.Git.Revision = ptr.To("develop")
+		Expect(trigger.Match(b, event)).To(BeNil())
+
+		event.Branch = "develop"
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+	})
+
+	It("uses the branches of the trigger condition", func() {
+		b.Spec.Source.Git.Revision = ptr.To("develop")
+		b.Spec.Trigger.When[0].GitHub.Branches = []string{"main", "release-*"}
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+		event.Branch = "release-1.2"
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+		event.Branch = "develop"
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	It("matches tag events of other providers regardless of the branches", func() {
+		b.Spec.Source.Git.URL = "https://gitlab.example.com/shipwright-io/sample-go.git"
+		b.Spec.Trigger.When = append(b.Spec.Trigger.When, build.TriggerWhen{
+			Name: "gitlab tags",
+			Type: build.GitLabWebHookTrigger,
+			GitLab: &build.WhenGitLab{
+				Events:   []build.GitLabEventName{build.GitLabTagPushEvent},
+				Branches: []string{"main"},
+			},
+		})
+
+		event = &trigger.Event{
+			Type:           build.GitLabWebHookTrigger,
+			Name:           string(build.GitLabTagPushEvent),
+			RepositoryURLs: []string{"https://gitlab.example.com/shipwright-io/sample-go"},
+			Tag:            "v1.2.3",
+		}
+
+		when := trigger.Match(b, event)
+		Expect(when).ToNot(BeNil())
+		Expect(when.Name).To(Equal("gitlab tags"))
+	})
+
+	It("matches tag events against the tags of the trigger condition", func() {
+		b.Spec.Trigger.When[0].GitHub.Events = []build.GitHubEventName{build.GitHubTagEvent, build.GitHubReleaseEvent}
+		b.Spec.Trigger.When[0].GitHub.Tags = []string{"v*.*.*"}
+		event.Name = string(build.GitHubTagEvent)
+		event.Branch = ""
+		event.Tag = "v1.4.2"
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+		event.Name = string(build.GitHubReleaseEvent)
+		Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+		event.Tag = "nightly"
+		Expect(trigger.Match(b, event)).To(BeNil())
+	})
+
+	Context("changed files", func() {
+		BeforeEach(func() {
+			event.ChangedFiles = []string{"services/api/main.go", "docs/README.md"}
+		})
+
+		It("matches when the changes are unknown", func() {
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"services/web"}
+			event.ChangedFiles = nil
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+		})
+
+		It("matches when a changed file is in one of the paths", func() {
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"services/web", "services/api"}
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+		})
+
+		It("does not match when no changed file is in the paths", func() {
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"services/web"}
+			Expect(trigger.Match(b, event)).To(BeNil())
+		})
+
+		It("supports glob patterns", func() {
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"services/*/*.go"}
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"**/*.go"}
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"**/*.yaml"}
+			Expect(trigger.Match(b, event)).To(BeNil())
+		})
+
+		It("uses the context directory when no paths are listed", func() {
+			b.Spec.Source.ContextDir = ptr.To("services/web/")
+			Expect(trigger.Match(b, event)).To(BeNil())
+
+			b.Spec.Source.ContextDir = ptr.To("services/api")
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+			b.Spec.Source.ContextDir = ptr.To(".")
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+		})
+
+		It("does not match when all changed files are ignored", func() {
+			b.Spec.Trigger.When[0].GitHub.IgnorePaths = []string{"**/*.md"}
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+			b.Spec.Trigger.When[0].GitHub.IgnorePaths = []string{"**/*.md", "services/api/*_test.go", "services/api/main.go"}
+			Expect(trigger.Match(b, event)).To(BeNil())
+		})
+
+		It("ignores files within the paths", func() {
+			b.Spec.Trigger.When[0].GitHub.Paths = []string{"services/api", "docs"}
+			b.Spec.Trigger.When[0].GitHub.IgnorePaths = []string{"services/api"}
+			Expect(trigger.Match(b, event)).ToNot(BeNil())
+
+			b.Spec.Trigger.When[0].GitHub.IgnorePaths = []string{"services/api", "docs/*.md"}
+			Expect(trigger.Match(b, event)).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("ChangedFiles", func() {
+	It("returns the distinct files of all commits", func() {
+		Expect(trigger.ChangedFiles([]trigger.Commit{
+			{Added: []string{"a.go"}, Modified: []string{"b.go"}},
+			{Removed: []string{"c.go"}, Modified: []string{"a.go"}},
+		})).To(Equal([]string{"a.go", "b.go", "c.go"}))
+	})
+
+	It("returns nil when there are no commits", func() {
+		Expect(trigger.ChangedFiles(nil)).To(BeNil())
+	})
+})
+
+var _ = Describe("MatchObjectRef", func() {
+	var b *build.Build
+
+	BeforeEach(func() {
+		b = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-go",
+				Namespace: "default",
+			},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name: "tests passed",
+						Type: build.PipelineTrigger,
+						ObjectRef: &build.WhenObjectRef{
+							Name:   "unit-tests",
+							Status: []string{"Succeeded"},
+						},
+					}, {
+						Name: "labeled pipeline passed",
+						Type: build.PipelineTrigger,
+						ObjectRef: &build.WhenObjectRef{
+							Selector: map[string]string{"app": "sample-go"},
+							Status:   []string{"Succeeded", "Completed"},
+						},
+					}},
+				},
+			},
+		}
+	})
+
+	It("matches an object by name and status", func() {
+		when := trigger.MatchObjectRef(b, build.PipelineTrigger, []string{"unit-tests-x7k2p", "unit-tests"}, nil, []string{"Succeeded"})
+		Expect(when).ToNot(BeNil())
+		Expect(when.Name).To(Equal("tests passed"))
+	})
+
+	It("matches an object by label selector", func() {
+		when := trigger.MatchObjectRef(b, build.PipelineTrigger, []string{"other"}, map[string]string{"app": "sample-go", "team": "a"}, []string{"Completed"})
+		Expect(when).ToNot(BeNil())
+		Expect(when.Name).To(Equal("labeled pipeline passed"))
+	})
+
+	It("does not match an object with a status that is not listed", func() {
+		Expect(trigger.MatchObjectRef(b, build.PipelineTrigger, []string{"unit-tests"}, nil, []string{"Failed"})).To(BeNil())
+	})
+
+	It("does not match an object with different name and labels", func() {
+		Expect(trigger.MatchObjectRef(b, build.PipelineTrigger, []string{"other"}, map[string]string{"app": "other"}, []string{"Succeeded"})).To(BeNil())
+	})
+
+	It("does not match a trigger of a different type", func() {
+		Expect(trigger.MatchObjectRef(b, build.ImageTrigger, []string{"unit-tests"}, nil, []string{"Succeeded"})).To(BeNil())
+	})
+})
+
+var _ = Describe("NewBuildRun", func() {
+	It("creates a BuildRun referencing the Build and pinned to the commit", func() {
+		b := &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-go",
+				Namespace: "default",
+			},
+		}
+		when := &build.TriggerWhen{
+			Name: "push on main",
+			Type: build.GitHubWebHookTrigger,
+		}
+
+		buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Revision: "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"})
+		Expect(buildRun.GenerateName).To(Equal("sample-go-"))
+		Expect(buildRun.Namespace).To(Equal("default"))
+		Expect(buildRun.Spec.BuildName()).To(Equal("sample-go"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerName, "push on main"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerType, "GitHub"))
+		Expect(buildRun.Spec.Source).ToNot(BeNil())
+		Expect(buildRun.Spec.Source.Type).To(Equal(build.GitType))
+		Expect(*buildRun.Spec.Source.Git.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+	})
+
+	It("creates a BuildRun referencing the object that caused the event", func() {
+		b := &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sample-go",
+				Namespace: "default",
+			},
+		}
+		when := &build.TriggerWhen{
+			Name: "tests passed",
+			Type: build.PipelineTrigger,
+		}
+
+		buildRun := trigger.NewBuildRun(b, when, &trigger.Event{
+			Type: build.PipelineTrigger,
+			Object: &corev1.ObjectReference{
+				Kind: "PipelineRun",
+				Name: "unit-tests-x7k2p",
+				UID:  "2a3c8f4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b",
+			},
+		})
+		Expect(buildRun.Spec.Source).To(BeNil())
+		Expect(buildRun.Labels).To(HaveKeyWithValue(build.LabelTriggerObjectUID, "2a3c8f4e-5b6d-4e7f-8a9b-0c1d2e3f4a5b"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerObjectKind, "PipelineRun"))
+		Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationTriggerObjectName, "unit-tests-x7k2p"))
+	})
+
+	Context("tag events", func() {
+		var (
+			b    *build.Build
+			when *build.TriggerWhen
+		)
+
+		BeforeEach(func() {
+			b = &build.Build{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample-go",
+					Namespace: "default",
+				},
+				Spec: build.BuildSpec{
+					Output: build.Image{
+						Image: "registry.example.com/org/app:latest",
+					},
+				},
+			}
+			when = &build.TriggerWhen{
+				Name:           "release",
+				Type:           build.GitHubWebHookTrigger,
+				TagOutputImage: ptr.To(true),
+			}
+		})
+
+		It("pins the revision to the tag when there is no commit SHA", func() {
+			buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Tag: "v1.4.2"})
+			Expect(*buildRun.Spec.Source.Git.Revision).To(Equal("v1.4.2"))
+		})
+
+		It("tags the output image with the Git tag name", func() {
+			buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Tag: "v1.4.2"})
+			Expect(buildRun.Spec.Output).ToNot(BeNil())
+			Expect(buildRun.Spec.Output.Image).To(Equal("registry.example.com/org/app:v1.4.2"))
+		})
+
+		It("replaces characters that are not allowed in image tags", func() {
+			b.Spec.Output.Image = "registry.example.com/org/app@sha256:2b0ab8f3a86e1f8b5f6ab8c5b1e2ad1a6f7b8a2e5c4c1f2e0b9c8d7e6f5a4b3c"
+			buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Tag: "release/1.0+build"})
+			Expect(buildRun.Spec.Output.Image).To(Equal("registry.example.com/org/app:release-1.0-build"))
+		})
+
+		It("keeps the output image when the trigger does not tag it", func() {
+			when.TagOutputImage = nil
+			buildRun := trigger.NewBuildRun(b, when, &trigger.Event{Tag: "v1.4.2"})
+			Expect(buildRun.Spec.Output).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("BuildRunName", func() {
+	b := &build.Build{ObjectMeta: metav1.ObjectMeta{Name: "nightly-build"}}
+
+	It("returns the same name for the same key", func() {
+		Expect(trigger.BuildRunName(b, "nightly@2024-01-02T03:00:00Z")).To(Equal(trigger.BuildRunName(b, "nightly@2024-01-02T03:00:00Z")))
+		Expect(trigger.BuildRunName(b, "nightly@2024-01-02T03:00:00Z")).To(MatchRegexp(`^nightly-build-[0-9a-f]{10}$`))
+	})
+
+	It("returns different names for different keys", func() {
+		Expect(trigger.BuildRunName(b, "nightly@2024-01-02T03:00:00Z")).ToNot(Equal(trigger.BuildRunName(b, "nightly@2024-01-03T03:00:00Z")))
+	})
+
+	It("shortens the name of the Build", func() {
+		long := &build.Build{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 51) + "-" + strings.Repeat("b", 11)}}
+		name := trigger.BuildRunName(long, "key")
+		Expect(len(name)).To(BeNumerically("<=", 63))
+		Expect(name).To(MatchRegexp(`^a{51}-[0-9a-f]{10}$`))
+	})
+})
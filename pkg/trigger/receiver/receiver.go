@@ -0,0 +1,247 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+	"github.com/shipwright-io/build/pkg/trigger/bitbucket"
+	"github.com/shipwright-io/build/pkg/trigger/gitea"
+	"github.com/shipwright-io/build/pkg/trigger/github"
+	"github.com/shipwright-io/build/pkg/trigger/gitlab"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+
+	// maxPayloadSize is the maximum size of a webhook request body, GitHub uses the same limit
+	maxPayloadSize = 25 * 1024 * 1024
+)
+
+var errUnauthorized = errors.New("the request could not be verified for any of the matching builds")
+
+// response is the body returned to the Git service
+type response struct {
+	Message   string   `json:"message"`
+	BuildRuns []string `json:"buildRuns,omitempty"`
+}
+
+// Receiver is an HTTP server accepting Git service webhook requests. For every Build with a
+// trigger condition matching the event, it creates a BuildRun.
+type Receiver struct {
+	ctx       context.Context
+	config    *config.Config
+	client    client.Client
+	providers []trigger.Provider
+}
+
+var _ manager.LeaderElectionRunnable = &Receiver{}
+
+// Add creates the webhook receiver and adds it to the Manager, if it is enabled in the configuration.
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+	if !c.Triggers.WebhookEnabled {
+		return nil
+	}
+
+	ctx = ctxlog.NewContext(ctx, "trigger-webhook-receiver")
+
+	// Gitea also sends the GitHub event header, the order ensures that Gitea requests are detected as such
+	return mgr.Add(NewReceiver(ctx, c, mgr.GetClient(),
+		gitea.Provider{},
+		gitlab.Provider{},
+		bitbucket.Provider{},
+		github.Provider{},
+	))
+}
+
+// NewReceiver returns a new Receiver handling requests of the given providers
+func NewReceiver(ctx context.Context, c *config.Config, client client.Client, providers ...trigger.Provider) *Receiver {
+	return &Receiver{
+		ctx:       ctx,
+		config:    c,
+		client:    client,
+		providers: providers,
+	}
+}
+
+// NeedLeaderElection returns false as every replica can serve webhook requests
+func (r *Receiver) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves webhook requests until the context is done
+func (r *Receiver) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", r.config.Triggers.WebhookPort),
+		Handler:           r,
+		ReadHeaderTimeout: 32 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		ctxlog.Info(r.ctx, "starting trigger webhook receiver", "port", r.config.Triggers.WebhookPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+
+	case <-ctx.Done():
+		ctxlog.Info(r.ctx, "shutting down trigger webhook receiver")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// ServeHTTP handles a single webhook request
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, response{Message: "only POST requests are supported"})
+		return
+	}
+
+	provider := r.detectProvider(req.Header)
+	if provider == nil {
+		writeResponse(w, http.StatusBadRequest, response{Message: "the request was not sent by a supported Git service"})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadSize+1))
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, response{Message: fmt.Sprintf("failed to read the request: %v", err)})
+		return
+	}
+
+	if len(body) > maxPayloadSize {
+		writeResponse(w, http.StatusRequestEntityTooLarge, response{Message: "the request exceeds the maximum payload size"})
+		return
+	}
+
+	event, err := provider.Parse(req.Header, body)
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, response{Message: err.Error()})
+		return
+	}
+
+	if event == nil {
+		writeResponse(w, http.StatusOK, response{Message: "the event is not relevant for triggering builds"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	buildRuns, err := r.triggerBuilds(ctx, provider, event, req.Header, body)
+	switch {
+	case errors.Is(err, errUnauthorized):
+		writeResponse(w, http.StatusUnauthorized, response{Message: err.Error()})
+
+	case err != nil:
+		ctxlog.Error(ctx, err, "failed to trigger builds")
+		writeResponse(w, http.StatusInternalServerError, response{Message: err.Error(), BuildRuns: buildRuns})
+
+	case len(buildRuns) == 0:
+		writeResponse(w, http.StatusOK, response{Message: "no build matches the event"})
+
+	default:
+		writeResponse(w, http.StatusAccepted, response{Message: "buildruns created", BuildRuns: buildRuns})
+	}
+}
+
+func (r *Receiver) detectProvider(header http.Header) trigger.Provider {
+	for _, provider := range r.providers {
+		if provider.Detect(header) {
+			return provider
+		}
+	}
+	return nil
+}
+
+// triggerBuilds creates a BuildRun for every Build matching the event, it returns the names of the
+// BuildRuns that were created.
+func (r *Receiver) triggerBuilds(ctx context.Context, provider trigger.Provider, event *trigger.Event, header http.Header, body []byte) ([]string, error) {
+	buildList := &build.BuildList{}
+	if err := r.client.List(ctx, buildList); err != nil {
+		return nil, err
+	}
+
+	var buildRuns []string
+	var rejected int
+	for i := range buildList.Items {
+		b := &buildList.Items[i]
+
+		when := trigger.Match(b, event)
+		if when == nil {
+			continue
+		}
+
+		if err := r.verify(ctx, provider, b, header, body); err != nil {
+			ctxlog.Info(ctx, "webhook request could not be verified", namespace, b.Namespace, name, b.Name, "error", err)
+			rejected++
+			continue
+		}
+
+		buildRun := trigger.NewBuildRun(b, when, event)
+		if err := r.client.Create(ctx, buildRun); err != nil {
+			return buildRuns, err
+		}
+
+		ctxlog.Info(ctx, "created BuildRun for trigger", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "buildrun", buildRun.Name)
+		buildRuns = append(buildRuns, fmt.Sprintf("%s/%s", buildRun.Namespace, buildRun.Name))
+	}
+
+	if len(buildRuns) == 0 && rejected > 0 {
+		return nil, errUnauthorized
+	}
+
+	return buildRuns, nil
+}
+
+// verify checks the request using the token of the trigger secret of the Build, Builds without a
+// trigger secret reject every request, as an unverified request could pin any revision.
+func (r *Receiver) verify(ctx context.Context, provider trigger.Provider, b *build.Build, header http.Header, body []byte) error {
+	if b.Spec.Trigger.TriggerSecret == nil {
+		return errors.New("the build has no trigger secret to verify the request")
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: b.Namespace, Name: *b.Spec.Trigger.TriggerSecret}, secret); err != nil {
+		return err
+	}
+
+	token, ok := secret.Data[build.TriggerSecretTokenKey]
+	if !ok {
+		return fmt.Errorf("the secret %s does not contain the key %q", secret.Name, build.TriggerSecretTokenKey)
+	}
+
+	return provider.Verify(header, body, token)
+}
+
+func writeResponse(w http.ResponseWriter, status int, body response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
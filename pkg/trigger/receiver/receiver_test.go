@@ -0,0 +1,185 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package receiver_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/trigger/github"
+	"github.com/shipwright-io/build/pkg/trigger/receiver"
+)
+
+const pushPayload = `{
+  "ref": "refs/heads/main",
+  "after": "4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a",
+  "repository": {
+    "html_url": "https://github.com/shipwright-io/sample-go",
+    "clone_url": "https://github.com/shipwright-io/sample-go.git"
+  }
+}`
+
+func triggeredBuild(name string, url string) build.Build {
+	return build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: build.BuildSpec{
+			Source: &build.Source{
+				Type: build.GitType,
+				Git: &build.Git{
+					URL: url,
+				},
+			},
+			Trigger: &build.Trigger{
+				TriggerSecret: ptr.To("webhook-secret"),
+				When: []build.TriggerWhen{{
+					Name: "push on main",
+					Type: build.GitHubWebHookTrigger,
+					GitHub: &build.WhenGitHub{
+						Events: []build.GitHubEventName{build.GitHubPushEvent},
+					},
+				}},
+			},
+		},
+	}
+}
+
+var _ = Describe("Receiver", func() {
+	var (
+		client    *fakes.FakeClient
+		builds    []build.Build
+		secret    *corev1.Secret
+		buildRuns []*build.BuildRun
+		recorder  *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		builds = []build.Build{
+			triggeredBuild("sample-go", "https://github.com/shipwright-io/sample-go"),
+			triggeredBuild("other", "https://github.com/shipwright-io/build"),
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook-secret", Namespace: "default"},
+			Data:       map[string][]byte{build.TriggerSecretTokenKey: []byte("s3cr3t")},
+		}
+		buildRuns = nil
+		recorder = httptest.NewRecorder()
+
+		client = &fakes.FakeClient{}
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			switch list := list.(type) {
+			case *build.BuildList:
+				list.Items = builds
+			}
+			return nil
+		})
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *corev1.Secret:
+				if secret != nil && secret.Name == nn.Name {
+					secret.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return errors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			switch object := object.(type) {
+			case *build.BuildRun:
+				buildRuns = append(buildRuns, object)
+			}
+			return nil
+		})
+	})
+
+	send := func(header http.Header, body string) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		r := receiver.NewReceiver(context.TODO(), config.NewDefaultConfig(), client, github.Provider{})
+		r.ServeHTTP(recorder, req)
+	}
+
+	githubHeader := func(event string) http.Header {
+		header := http.Header{}
+		header.Set("X-GitHub-Event", event)
+		return header
+	}
+
+	signedGitHubHeader := func(event string, body string) http.Header {
+		mac := hmac.New(sha256.New, []byte("s3cr3t"))
+		mac.Write([]byte(body))
+
+		header := githubHeader(event)
+		header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	It("rejects requests from unknown senders", func() {
+		send(http.Header{}, pushPayload)
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(buildRuns).To(BeEmpty())
+	})
+
+	It("ignores events that are not relevant", func() {
+		send(githubHeader("ping"), `{}`)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(client.ListCallCount()).To(Equal(0))
+	})
+
+	It("creates a BuildRun for the matching Build only", func() {
+		send(signedGitHubHeader("push", pushPayload), pushPayload)
+		Expect(recorder.Code).To(Equal(http.StatusAccepted))
+		Expect(buildRuns).To(HaveLen(1))
+		Expect(buildRuns[0].Spec.BuildName()).To(Equal("sample-go"))
+		Expect(*buildRuns[0].Spec.Source.Git.Revision).To(Equal("4f0b6dbb1e2c5f1a4a8c7d2f1e0a9b8c7d6e5f4a"))
+	})
+
+	It("rejects an unsigned request", func() {
+		send(githubHeader("push"), pushPayload)
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(buildRuns).To(BeEmpty())
+	})
+
+	It("rejects a request with a wrong signature", func() {
+		header := githubHeader("push")
+		header.Set("X-Hub-Signature-256", "sha256=0000")
+
+		send(header, pushPayload)
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(buildRuns).To(BeEmpty())
+	})
+
+	It("rejects requests for a Build without a trigger secret", func() {
+		builds[0].Spec.Trigger.TriggerSecret = nil
+
+		send(signedGitHubHeader("push", pushPayload), pushPayload)
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(buildRuns).To(BeEmpty())
+	})
+})
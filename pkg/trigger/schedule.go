@@ -0,0 +1,53 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// ParseSchedule parses the cron expression of a Schedule trigger. The returned schedule
+// computes activation times in the time zone of the trigger, which defaults to UTC.
+func ParseSchedule(whenSchedule *build.WhenSchedule) (cron.Schedule, error) {
+	// time zones are configured using a separate attribute, the
+	// cron library would otherwise silently accept them as a prefix
+	if strings.HasPrefix(strings.TrimSpace(whenSchedule.Cron), "TZ=") || strings.HasPrefix(strings.TrimSpace(whenSchedule.Cron), "CRON_TZ=") {
+		return nil, fmt.Errorf("the cron expression %q must not contain a time zone, use the timeZone attribute instead", whenSchedule.Cron)
+	}
+
+	location := time.UTC
+	if whenSchedule.TimeZone != nil && *whenSchedule.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(*whenSchedule.TimeZone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q: %w", *whenSchedule.TimeZone, err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(whenSchedule.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", whenSchedule.Cron, err)
+	}
+
+	if specSchedule, ok := schedule.(*cron.SpecSchedule); ok {
+		specSchedule.Location = location
+	}
+
+	return schedule, nil
+}
+
+// ConcurrencyPolicy returns the concurrency policy of a Schedule trigger, defaulting to Allow
+func ConcurrencyPolicy(whenSchedule *build.WhenSchedule) build.ScheduleConcurrencyPolicy {
+	if whenSchedule.ConcurrencyPolicy == nil || *whenSchedule.ConcurrencyPolicy == "" {
+		return build.ScheduleConcurrencyAllow
+	}
+
+	return *whenSchedule.ConcurrencyPolicy
+}
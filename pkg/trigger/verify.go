@@ -0,0 +1,47 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// VerifyHMACSHA256 checks that the hex encoded signature is the HMAC SHA-256 of the body using the token
+func VerifyHMACSHA256(signature string, body []byte, token []byte) error {
+	if signature == "" {
+		return errors.New("request is not signed")
+	}
+
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("request signature is malformed: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, token)
+	mac.Write(body)
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return errors.New("request signature does not match")
+	}
+
+	return nil
+}
+
+// VerifyToken checks that the token sent with the request equals the expected token
+func VerifyToken(actual string, token []byte) error {
+	if actual == "" {
+		return errors.New("request does not contain a token")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(actual), token) != 1 {
+		return errors.New("request token does not match")
+	}
+
+	return nil
+}
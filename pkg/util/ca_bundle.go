@@ -0,0 +1,100 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// CABundleFileEnvVar is the environment variable that points to a file with PEM encoded CA certificates
+// that are trusted in addition to the system roots. The BuildRun controller sets it on the steps that
+// Shipwright adds to a build.
+const CABundleFileEnvVar = "SHP_CA_BUNDLE_FILE"
+
+// systemCABundleFiles are the locations of the system CA bundle of common distributions
+var systemCABundleFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",                // Debian, Ubuntu
+	"/etc/pki/tls/certs/ca-bundle.crt",                  // Fedora, RHEL
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem", // CentOS, RHEL 7
+	"/etc/ssl/ca-bundle.pem",                            // OpenSUSE
+	"/etc/ssl/cert.pem",                                 // Alpine
+}
+
+// CABundleFile returns the path of the configured CA bundle, or an empty string if no CA bundle is
+// configured or the file does not exist
+func CABundleFile() string {
+	path := os.Getenv(CABundleFileEnvVar)
+	if path == "" {
+		return ""
+	}
+
+	if fileInfo, err := os.Stat(path); err != nil || fileInfo.IsDir() {
+		return ""
+	}
+
+	return path
+}
+
+// RootCAs returns the system roots together with the certificates of the configured CA bundle. It
+// returns nil if there is no CA bundle, so that the system roots are used.
+func RootCAs() (*x509.CertPool, error) {
+	caBundle, err := CABundle()
+	if err != nil || caBundle == nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	pool.AppendCertsFromPEM(caBundle)
+	return pool, nil
+}
+
+// CABundleWithSystemRoots returns the PEM encoded system roots followed by the certificates of the
+// configured CA bundle, for tools that only accept a single file of trusted certificates. It returns
+// nil if there is no CA bundle.
+func CABundleWithSystemRoots() ([]byte, error) {
+	caBundle, err := CABundle()
+	if err != nil || caBundle == nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, systemCABundleFile := range systemCABundleFiles {
+		if data, err := os.ReadFile(systemCABundleFile); err == nil {
+			buf.Write(data)
+			buf.WriteString("\n")
+			break
+		}
+	}
+
+	buf.Write(caBundle)
+	return buf.Bytes(), nil
+}
+
+// CABundle returns the PEM encoded certificates of the configured CA bundle, or nil if no CA bundle is
+// configured
+func CABundle() ([]byte, error) {
+	path := CABundleFile()
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("the CA bundle %s does not contain any PEM encoded certificate", path)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,117 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/util"
+)
+
+var _ = Describe("CA bundle", func() {
+	var certificate *x509.Certificate
+	var caBundleFile string
+
+	BeforeEach(func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: "Shipwright Test CA"},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		certificate, err = x509.ParseCertificate(der)
+		Expect(err).ToNot(HaveOccurred())
+
+		caBundleFile = filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt")
+		Expect(os.WriteFile(caBundleFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)).To(Succeed())
+	})
+
+	Context("when no CA bundle is configured", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv(util.CABundleFileEnvVar, "")
+		})
+
+		It("uses the system roots", func() {
+			Expect(util.CABundleFile()).To(BeEmpty())
+
+			pool, err := util.RootCAs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pool).To(BeNil())
+
+			caBundle, err := util.CABundleWithSystemRoots()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(caBundle).To(BeNil())
+		})
+	})
+
+	Context("when the configured CA bundle does not exist", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv(util.CABundleFileEnvVar, filepath.Join(GinkgoT().TempDir(), "ca-bundle.crt"))
+		})
+
+		It("uses the system roots", func() {
+			Expect(util.CABundleFile()).To(BeEmpty())
+
+			pool, err := util.RootCAs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pool).To(BeNil())
+		})
+	})
+
+	Context("when a CA bundle is configured", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv(util.CABundleFileEnvVar, caBundleFile)
+		})
+
+		It("trusts its certificates", func() {
+			Expect(util.CABundleFile()).To(Equal(caBundleFile))
+
+			pool, err := util.RootCAs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pool).ToNot(BeNil())
+
+			_, err = certificate.Verify(x509.VerifyOptions{Roots: pool})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("appends its certificates to the system roots", func() {
+			caBundle, err := util.CABundleWithSystemRoots()
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := os.ReadFile(caBundleFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(caBundle)).To(HaveSuffix(string(data)))
+		})
+
+		It("fails when the CA bundle contains no certificate", func() {
+			Expect(os.WriteFile(caBundleFile, []byte("not a certificate"), 0644)).To(Succeed())
+
+			_, err := util.RootCAs()
+			Expect(err).To(MatchError(ContainSubstring("does not contain any PEM encoded certificate")))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+// BundleLimitsRef contains all required fields
+// to validate the limits of source bundles
+type BundleLimitsRef struct {
+	Build  *build.Build               // build instance for analysis
+	Limits config.BundleLimitsOptions // limits that the cluster administrator configured
+}
+
+func NewBundleLimits(build *build.Build, limits config.BundleLimitsOptions) *BundleLimitsRef {
+	return &BundleLimitsRef{build, limits}
+}
+
+// ValidatePath implements BuildPath interface and validates
+// that the limits of source bundles do not exceed the configured ones
+func (b *BundleLimitsRef) ValidatePath(_ context.Context) error {
+	if b.Build.Spec.Source != nil && b.Build.Spec.Source.OCIArtifact != nil {
+		if message := b.validateLimits(b.Build.Spec.Source.OCIArtifact.Limits); message != "" {
+			b.Build.Status.Reason = ptr.To(build.BundleLimitsNotValid)
+			b.Build.Status.Message = ptr.To(message)
+			return nil
+		}
+	}
+
+	for _, source := range b.Build.Spec.Sources {
+		if source.OCIArtifact == nil {
+			continue
+		}
+
+		if message := b.validateLimits(source.OCIArtifact.Limits); message != "" {
+			b.Build.Status.Reason = ptr.To(build.BundleLimitsNotValid)
+			b.Build.Status.Message = ptr.To(fmt.Sprintf("source %q: %s", source.Name, message))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// validateLimits returns a message describing the first limit that is not greater than 0 or that exceeds the
+// configured one, or an empty string. A configured limit of 0 means that there is no limit.
+func (b *BundleLimitsRef) validateLimits(limits *build.BundleLimits) string {
+	if limits == nil {
+		return ""
+	}
+
+	if limits.MaxSize != nil {
+		if limits.MaxSize.Sign() <= 0 {
+			return fmt.Sprintf("maxSize %s is not greater than 0", limits.MaxSize.String())
+		}
+
+		if b.Limits.MaxSize.Sign() > 0 && limits.MaxSize.Cmp(b.Limits.MaxSize) > 0 {
+			return fmt.Sprintf("maxSize %s exceeds the maximum of %s", limits.MaxSize.String(), b.Limits.MaxSize.String())
+		}
+	}
+
+	if limits.MaxFileSize != nil {
+		if limits.MaxFileSize.Sign() <= 0 {
+			return fmt.Sprintf("maxFileSize %s is not greater than 0", limits.MaxFileSize.String())
+		}
+
+		if b.Limits.MaxFileSize.Sign() > 0 && limits.MaxFileSize.Cmp(b.Limits.MaxFileSize) > 0 {
+			return fmt.Sprintf("maxFileSize %s exceeds the maximum of %s", limits.MaxFileSize.String(), b.Limits.MaxFileSize.String())
+		}
+	}
+
+	if limits.MaxEntries != nil {
+		if *limits.MaxEntries <= 0 {
+			return fmt.Sprintf("maxEntries %d is not greater than 0", *limits.MaxEntries)
+		}
+
+		if b.Limits.MaxEntries > 0 && *limits.MaxEntries > b.Limits.MaxEntries {
+			return fmt.Sprintf("maxEntries %d exceeds the maximum of %d", *limits.MaxEntries, b.Limits.MaxEntries)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,132 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	. "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("ValidateBundleLimits", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+	})
+
+	var limits config.BundleLimitsOptions
+
+	BeforeEach(func() {
+		limits = config.NewDefaultConfig().BundleLimits
+	})
+
+	var validate = func(build *Build) {
+		GinkgoHelper()
+
+		var validator = validate.NewBundleLimits(build, limits)
+		Expect(validator.ValidatePath(ctx)).To(Succeed())
+	}
+
+	var sampleBuild = func(limits *BundleLimits) *Build {
+		return &Build{
+			Spec: BuildSpec{
+				Source: &Source{
+					Type: OCIArtifactType,
+					OCIArtifact: &OCIArtifact{
+						Image:  "registry.example.com/org/source:latest",
+						Limits: limits,
+					},
+				},
+			},
+		}
+	}
+
+	It("should pass for a Build without limits", func() {
+		build := sampleBuild(nil)
+		validate(build)
+		Expect(build.Status.Reason).To(BeNil())
+	})
+
+	It("should pass for limits that are lower than the configured ones", func() {
+		build := sampleBuild(&BundleLimits{
+			MaxSize:     ptr.To(resource.MustParse("500Mi")),
+			MaxFileSize: ptr.To(resource.MustParse("1Gi")),
+			MaxEntries:  ptr.To[int64](1000),
+		})
+		validate(build)
+		Expect(build.Status.Reason).To(BeNil())
+	})
+
+	It("should fail for a maximum size that exceeds the configured one", func() {
+		build := sampleBuild(&BundleLimits{
+			MaxSize: ptr.To(resource.MustParse("8Gi")),
+		})
+		validate(build)
+		Expect(*build.Status.Reason).To(Equal(BundleLimitsNotValid))
+		Expect(*build.Status.Message).To(Equal("maxSize 8Gi exceeds the maximum of 4Gi"))
+	})
+
+	It("should fail for a maximum number of entries that exceeds the configured one", func() {
+		build := sampleBuild(nil)
+		build.Spec.Source = nil
+		build.Spec.Sources = []NamedSource{{
+			Name: "assets",
+			Type: OCIArtifactType,
+			OCIArtifact: &OCIArtifact{
+				Image: "registry.example.com/org/assets:latest",
+				Limits: &BundleLimits{
+					MaxEntries: ptr.To[int64](250000),
+				},
+			},
+		}}
+		validate(build)
+		Expect(*build.Status.Reason).To(Equal(BundleLimitsNotValid))
+		Expect(*build.Status.Message).To(Equal(`source "assets": maxEntries 250000 exceeds the maximum of 100000`))
+	})
+
+	It("should fail for limits that are not greater than 0", func() {
+		build := sampleBuild(&BundleLimits{
+			MaxSize: ptr.To(resource.MustParse("0")),
+		})
+		validate(build)
+		Expect(*build.Status.Reason).To(Equal(BundleLimitsNotValid))
+		Expect(*build.Status.Message).To(Equal("maxSize 0 is not greater than 0"))
+
+		build = sampleBuild(&BundleLimits{
+			MaxFileSize: ptr.To(resource.MustParse("-1Mi")),
+		})
+		validate(build)
+		Expect(*build.Status.Reason).To(Equal(BundleLimitsNotValid))
+		Expect(*build.Status.Message).To(Equal("maxFileSize -1Mi is not greater than 0"))
+
+		build = sampleBuild(&BundleLimits{
+			MaxEntries: ptr.To[int64](0),
+		})
+		validate(build)
+		Expect(*build.Status.Reason).To(Equal(BundleLimitsNotValid))
+		Expect(*build.Status.Message).To(Equal("maxEntries 0 is not greater than 0"))
+	})
+
+	It("should pass for any positive limits when the configuration has no limits", func() {
+		limits = config.BundleLimitsOptions{}
+
+		build := sampleBuild(&BundleLimits{
+			MaxSize:     ptr.To(resource.MustParse("8Gi")),
+			MaxFileSize: ptr.To(resource.MustParse("2Gi")),
+			MaxEntries:  ptr.To[int64](250000),
+		})
+		validate(build)
+		Expect(build.Status.Reason).To(BeNil())
+	})
+})
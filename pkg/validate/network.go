@@ -0,0 +1,49 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// NetworkRef contains all required fields
+// to validate the network settings of a Build
+type NetworkRef struct {
+	Build  *build.Build
+	Client client.Client
+}
+
+func NewNetwork(client client.Client, build *build.Build) *NetworkRef {
+	return &NetworkRef{build, client}
+}
+
+// ValidatePath implements BuildPath interface and validates
+// that the ConfigMap with the network settings exists
+func (n *NetworkRef) ValidatePath(ctx context.Context) error {
+	if n.Build.Spec.Network == nil {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := n.Client.Get(ctx, types.NamespacedName{Name: n.Build.Spec.Network.ConfigMap, Namespace: n.Build.Namespace}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		n.Build.Status.Reason = ptr.To(build.NetworkConfigMapNotFound)
+		n.Build.Status.Message = ptr.To(fmt.Sprintf("referenced ConfigMap %s not found", n.Build.Spec.Network.ConfigMap))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	. "github.com/shipwright-io/build/pkg/validate"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Network", func() {
+	var ctx context.Context
+	var client *fakes.FakeClient
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		client = &fakes.FakeClient{}
+	})
+
+	var sampleBuild = func(network *build.BuildNetwork) *build.Build {
+		return &build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+			Spec: build.BuildSpec{
+				Network: network,
+			},
+		}
+	}
+
+	It("should pass when the Build has no network settings", func() {
+		sample := sampleBuild(nil)
+
+		Expect(NewNetwork(client, sample).ValidatePath(ctx)).To(Succeed())
+		Expect(sample.Status.Reason).To(BeNil())
+		Expect(client.GetCallCount()).To(Equal(0))
+	})
+
+	It("should pass when the referenced ConfigMap exists", func() {
+		sample := sampleBuild(&build.BuildNetwork{ConfigMap: "network"})
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			Expect(nn).To(Equal(types.NamespacedName{Namespace: "foo", Name: "network"}))
+			Expect(object).To(BeAssignableToTypeOf(&corev1.ConfigMap{}))
+			return nil
+		})
+
+		Expect(NewNetwork(client, sample).ValidatePath(ctx)).To(Succeed())
+		Expect(sample.Status.Reason).To(BeNil())
+	})
+
+	It("should fail when the referenced ConfigMap does not exist", func() {
+		sample := sampleBuild(&build.BuildNetwork{ConfigMap: "network"})
+		client.GetReturns(errors.NewNotFound(schema.GroupResource{}, "network"))
+
+		Expect(NewNetwork(client, sample).ValidatePath(ctx)).To(Succeed())
+		Expect(*sample.Status.Reason).To(Equal(build.NetworkConfigMapNotFound))
+		Expect(*sample.Status.Message).To(Equal("referenced ConfigMap network not found"))
+	})
+
+	It("should error when there is an unexpected result", func() {
+		sample := sampleBuild(&build.BuildNetwork{ConfigMap: "network"})
+		client.GetReturns(errors.NewInternalError(fmt.Errorf("monkey wrench")))
+
+		Expect(NewNetwork(client, sample).ValidatePath(ctx)).To(HaveOccurred())
+		Expect(sample.Status.Reason).To(BeNil())
+	})
+})
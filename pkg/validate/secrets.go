@@ -68,5 +68,22 @@ func (s Credentials) buildCredentialReferences() map[string]build.BuildReason {
 	if s.Build.GetSourceCredentials() != nil {
 		secretRefMap[*s.Build.GetSourceCredentials()] = build.SpecSourceSecretRefNotFound
 	}
+
+	for _, secretName := range s.Build.GetNamedSourcesCredentials() {
+		secretRefMap[secretName] = build.SpecSourceSecretRefNotFound
+	}
+
+	for _, secretName := range s.Build.GetSourceVerificationSecrets() {
+		secretRefMap[secretName] = build.SpecSourceSecretRefNotFound
+	}
+
+	for _, secretName := range s.Build.GetSourceCloneSecrets() {
+		secretRefMap[secretName] = build.SpecSourceSecretRefNotFound
+	}
+
+	if s.Build.Spec.Trigger != nil && s.Build.Spec.Trigger.TriggerSecret != nil {
+		secretRefMap[*s.Build.Spec.Trigger.TriggerSecret] = build.SpecTriggerSecretRefNotFound
+	}
+
 	return secretRefMap
 }
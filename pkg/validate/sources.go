@@ -7,44 +7,117 @@ package validate
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 )
 
+// reservedSourceNames are the names of the source steps that are not created for named sources
+var reservedSourceNames = map[string]struct{}{
+	"default": {},
+	"local":   {},
+}
+
 // SourcesRef implements RuntimeRef interface to add validations for `build.spec.source`.
 type SourceRef struct {
 	Build *build.Build // build instance for analysis
 }
 
-// ValidatePath executes the validation routine, inspecting the `build.spec.source` path
+// ValidatePath executes the validation routine, inspecting the `build.spec.source` and
+// `build.spec.sources` paths
 func (s *SourceRef) ValidatePath(_ context.Context) error {
 	if s.Build.Spec.Source != nil {
-		return s.validateSourceEntry(s.Build.Spec.Source)
+		if err := s.validateSourceEntry(s.Build.Spec.Source); err != nil {
+			return err
+		}
+	}
+
+	if message := validateNamedSources(s.Build.Spec.Sources); message != "" {
+		s.Build.Status.Reason = ptr.To(build.SourcesInvalid)
+		s.Build.Status.Message = ptr.To(message)
 	}
 
 	return nil
 }
 
+// validateNamedSources returns a message describing the first invalid named source, or an empty string
+func validateNamedSources(sources []build.NamedSource) string {
+	names := map[string]struct{}{}
+	targetDirectories := map[string]string{}
+
+	for _, source := range sources {
+		if errs := validation.IsDNS1123Label(source.Name); len(errs) > 0 {
+			return fmt.Sprintf("source name %q is not valid: %s", source.Name, strings.Join(errs, ", "))
+		}
+
+		if _, reserved := reservedSourceNames[source.Name]; reserved {
+			return fmt.Sprintf("source name %q is reserved", source.Name)
+		}
+
+		if _, duplicate := names[source.Name]; duplicate {
+			return fmt.Sprintf("source name %q is used more than once", source.Name)
+		}
+		names[source.Name] = struct{}{}
+
+		switch source.Type {
+		case build.GitType:
+			if source.Git == nil || source.OCIArtifact != nil || source.HTTP != nil {
+				return fmt.Sprintf("type of source %q does not match the source", source.Name)
+			}
+		case build.OCIArtifactType:
+			if source.OCIArtifact == nil || source.Git != nil || source.HTTP != nil {
+				return fmt.Sprintf("type of source %q does not match the source", source.Name)
+			}
+		case build.HTTPType:
+			if source.HTTP == nil || source.Git != nil || source.OCIArtifact != nil {
+				return fmt.Sprintf("type of source %q does not match the source", source.Name)
+			}
+		default:
+			return fmt.Sprintf("type %q of source %q is not supported, supported types are Git, OCI, and HTTP", source.Type, source.Name)
+		}
+
+		targetDirectory := filepath.Clean(source.GetTargetDirectory())
+		if filepath.IsAbs(targetDirectory) || targetDirectory == "." || targetDirectory == ".." || strings.HasPrefix(targetDirectory, "../") {
+			return fmt.Sprintf("target directory %q of source %q must be a sub-directory of the source root", source.GetTargetDirectory(), source.Name)
+		}
+
+		if other, duplicate := targetDirectories[targetDirectory]; duplicate {
+			return fmt.Sprintf("sources %q and %q use the same target directory %q", other, source.Name, targetDirectory)
+		}
+		targetDirectories[targetDirectory] = source.Name
+	}
+
+	return ""
+}
+
 // validateSourceEntry inspect informed entry, probes all required attributes.
 func (s *SourceRef) validateSourceEntry(source *build.Source) error {
 
 	// dont bail out if the Source object is empty, we preserve the old behaviour as in v1alpha1
 	if source.Type == "" && source.Git == nil &&
-		source.OCIArtifact == nil && source.Local == nil {
+		source.OCIArtifact == nil && source.HTTP == nil && source.Local == nil {
 		return nil
 	}
 
 	switch source.Type {
 	case build.GitType:
-		if source.Git == nil || source.OCIArtifact != nil || source.Local != nil {
+		if source.Git == nil || source.OCIArtifact != nil || source.HTTP != nil || source.Local != nil {
 			return fmt.Errorf("type does not match the source")
 		}
 	case build.OCIArtifactType:
-		if source.OCIArtifact == nil || source.Git != nil || source.Local != nil {
+		if source.OCIArtifact == nil || source.Git != nil || source.HTTP != nil || source.Local != nil {
+			return fmt.Errorf("type does not match the source")
+		}
+	case build.HTTPType:
+		if source.HTTP == nil || source.Git != nil || source.OCIArtifact != nil || source.Local != nil {
 			return fmt.Errorf("type does not match the source")
 		}
 	case build.LocalType:
-		if source.Local == nil || source.OCIArtifact != nil || source.Git != nil {
+		if source.Local == nil || source.OCIArtifact != nil || source.Git != nil || source.HTTP != nil {
 			return fmt.Errorf("type does not match the source")
 		}
 	case "":
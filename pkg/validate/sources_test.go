@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/utils/ptr"
+
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/validate"
 )
@@ -35,6 +37,32 @@ var _ = Describe("SourcesRef", func() {
 			Expect(srcRef.ValidatePath(context.TODO())).To(BeNil())
 		})
 
+		It("should successfully validate a build with HTTP source", func() {
+			srcRef := validate.NewSourceRef(&build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.HTTPType,
+						HTTP: &build.HTTP{URL: "https://example.com/releases/app-1.0.0.tar.gz"},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(BeNil())
+		})
+
+		It("should fail to validate if the type does not match the source http", func() {
+			srcRef := validate.NewSourceRef(&build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.GitType,
+						HTTP: &build.HTTP{URL: "https://example.com/releases/app-1.0.0.tar.gz"},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
+		})
+
 		It("should fail to validate if the type is not defined", func() {
 			srcRef := validate.NewSourceRef(&build.Build{
 				Spec: build.BuildSpec{
@@ -59,5 +87,77 @@ var _ = Describe("SourcesRef", func() {
 
 			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
 		})
+
+		Context("when named sources are defined", func() {
+			var b *build.Build
+
+			BeforeEach(func() {
+				b = &build.Build{
+					Spec: build.BuildSpec{
+						Sources: []build.NamedSource{
+							{
+								Name: "config",
+								Type: build.GitType,
+								Git:  &build.Git{URL: "https://github.com/shipwright-io/sample-config"},
+							},
+							{
+								Name:            "assets",
+								Type:            build.OCIArtifactType,
+								TargetDirectory: ptr.To("static/assets"),
+								OCIArtifact:     &build.OCIArtifact{Image: "ghcr.io/shipwright-io/sample-go/assets:latest"},
+							},
+						},
+					},
+				}
+			})
+
+			It("should successfully validate valid named sources", func() {
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(BeNil())
+			})
+
+			It("should fail for a reserved name", func() {
+				b.Spec.Sources[0].Name = "default"
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+				Expect(*b.Status.Message).To(ContainSubstring("reserved"))
+			})
+
+			It("should fail for duplicate names", func() {
+				b.Spec.Sources[1].Name = "config"
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+			})
+
+			It("should fail for a type that does not match the source", func() {
+				b.Spec.Sources[0].Type = build.OCIArtifactType
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+			})
+
+			It("should fail for the Local type", func() {
+				b.Spec.Sources[0].Type = build.LocalType
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+			})
+
+			It("should fail for a target directory outside of the source root", func() {
+				b.Spec.Sources[1].TargetDirectory = ptr.To("../assets")
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+			})
+
+			It("should fail when two sources use the same target directory", func() {
+				b.Spec.Sources[1].TargetDirectory = ptr.To("config/")
+
+				Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+				Expect(b.Status.Reason).To(Equal(ptr.To(build.SourcesInvalid)))
+			})
+		})
 	})
 })
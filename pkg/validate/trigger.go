@@ -6,12 +6,57 @@ package validate
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/trigger"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 )
 
+// webHooks holds for each webhook trigger type the attribute with its condition, the reason when the condition is
+// not valid, and the events that the webhook receiver supports.
+var webHooks = map[build.TriggerType]struct {
+	attribute string
+	reason    build.BuildReason
+	defined   func(when *build.TriggerWhen) bool
+	events    []string
+}{
+	build.GitHubWebHookTrigger: {
+		attribute: "github",
+		reason:    build.TriggerInvalidGitHubWebHook,
+		defined:   func(when *build.TriggerWhen) bool { return when.GitHub != nil },
+		events: []string{
+			string(build.GitHubPullRequestEvent), string(build.GitHubPushEvent), string(build.GitHubTagEvent), string(build.GitHubReleaseEvent),
+		},
+	},
+	build.GitLabWebHookTrigger: {
+		attribute: "gitlab",
+		reason:    build.TriggerInvalidGitLabWebHook,
+		defined:   func(when *build.TriggerWhen) bool { return when.GitLab != nil },
+		events: []string{
+			string(build.GitLabPushEvent), string(build.GitLabMergeRequestEvent), string(build.GitLabTagPushEvent),
+		},
+	},
+	build.GiteaWebHookTrigger: {
+		attribute: "gitea",
+		reason:    build.TriggerInvalidGiteaWebHook,
+		defined:   func(when *build.TriggerWhen) bool { return when.Gitea != nil },
+		events: []string{
+			string(build.GiteaPushEvent), string(build.GiteaPullRequestEvent), string(build.GiteaTagEvent),
+		},
+	},
+	build.BitbucketWebHookTrigger: {
+		attribute: "bitbucket",
+		reason:    build.TriggerInvalidBitbucketWebHook,
+		defined:   func(when *build.TriggerWhen) bool { return when.Bitbucket != nil },
+		events: []string{
+			string(build.BitbucketPushEvent), string(build.BitbucketPullRequestEvent), string(build.BitbucketTagEvent),
+		},
+	},
+}
+
 // Trigger implements the interface BuildPath with the objective of applying validations against the
 // `.spec.trigger` related attributes.
 type Trigger struct {
@@ -21,6 +66,20 @@ type Trigger struct {
 // validate goes through the trigger "when" conditions to validate each entry.
 func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 	var allErrs []error
+
+	// the receiver rejects webhook requests for Builds without a secret to verify them
+	if t.build.Spec.Trigger.TriggerSecret == nil || *t.build.Spec.Trigger.TriggerSecret == "" {
+		for _, when := range triggerWhen {
+			if _, isWebHook := webHooks[when.Type]; isWebHook {
+				t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerSecretNotDefined)
+				t.build.Status.Message = ptr.To(fmt.Sprintf(
+					"%q is a webhook trigger condition, but `.spec.trigger.triggerSecret` is not set", when.Name,
+				))
+				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+			}
+		}
+	}
+
 	for _, when := range triggerWhen {
 		if when.Name == "" {
 			t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerNameCanNotBeBlank)
@@ -29,22 +88,8 @@ func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 		}
 
 		switch when.Type {
-		case build.GitHubWebHookTrigger:
-			if when.GitHub == nil {
-				t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidGitHubWebHook)
-				t.build.Status.Message = ptr.To(fmt.Sprintf(
-					"%q is missing required attribute `.github`", when.Name,
-				))
-				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
-			} else {
-				if len(when.GitHub.Events) == 0 {
-					t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidGitHubWebHook)
-					t.build.Status.Message = ptr.To(fmt.Sprintf(
-						"%q is missing required attribute `.github.events`", when.Name,
-					))
-					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
-				}
-			}
+		case build.GitHubWebHookTrigger, build.GitLabWebHookTrigger, build.GiteaWebHookTrigger, build.BitbucketWebHookTrigger:
+			allErrs = append(allErrs, t.validateWebHook(&when)...)
 		case build.ImageTrigger:
 			if when.Image == nil {
 				t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidImage)
@@ -93,16 +138,80 @@ func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
 			}
+		case build.ScheduleTrigger:
+			if when.Schedule == nil {
+				t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidSchedule)
+				t.build.Status.Message = ptr.To(fmt.Sprintf(
+					"%q is missing required attribute `.schedule`", when.Name,
+				))
+				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+			} else {
+				if _, err := trigger.ParseSchedule(when.Schedule); err != nil {
+					t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidSchedule)
+					t.build.Status.Message = ptr.To(fmt.Sprintf(
+						"%q contains an invalid schedule: %v", when.Name, err,
+					))
+					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+				}
+				switch trigger.ConcurrencyPolicy(when.Schedule) {
+				case build.ScheduleConcurrencyAllow, build.ScheduleConcurrencySkip, build.ScheduleConcurrencyReplace:
+				default:
+					t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidSchedule)
+					t.build.Status.Message = ptr.To(fmt.Sprintf(
+						"%q contains an invalid concurrency policy %q", when.Name, *when.Schedule.ConcurrencyPolicy,
+					))
+					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+				}
+			}
 		default:
 			t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidType)
 			t.build.Status.Message = ptr.To(
 				fmt.Sprintf("%q contains an invalid type %q", when.Name, when.Type))
 			allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 		}
+
+		for _, pattern := range append(when.GetPaths(when.Type), when.GetIgnorePaths(when.Type)...) {
+			if err := trigger.ValidatePathPattern(pattern); err != nil {
+				t.build.Status.Reason = ptr.To[build.BuildReason](build.TriggerInvalidPathPattern)
+				t.build.Status.Message = ptr.To(fmt.Sprintf("%q contains an %v", when.Name, err))
+				allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
+			}
+		}
 	}
 	return allErrs
 }
 
+// validateWebHook validates the condition of a webhook trigger, it must be set and list at least one event
+// that the webhook receiver supports for the provider.
+func (t *Trigger) validateWebHook(when *build.TriggerWhen) []error {
+	webHook := webHooks[when.Type]
+
+	fail := func(format string, args ...any) error {
+		t.build.Status.Reason = ptr.To(webHook.reason)
+		t.build.Status.Message = ptr.To(fmt.Sprintf(format, args...))
+		return fmt.Errorf("%s", *t.build.Status.Message)
+	}
+
+	if !webHook.defined(when) {
+		return []error{fail("%q is missing required attribute `.%s`", when.Name, webHook.attribute)}
+	}
+
+	events := when.GetEvents(when.Type)
+	if len(events) == 0 {
+		return []error{fail("%q is missing required attribute `.%s.events`", when.Name, webHook.attribute)}
+	}
+
+	var errs []error
+	for _, event := range events {
+		if !slices.Contains(webHook.events, event) {
+			errs = append(errs, fail("%q contains an invalid event %q in `.%s.events`, supported are %s",
+				when.Name, event, webHook.attribute, strings.Join(webHook.events, ", ")))
+		}
+	}
+
+	return errs
+}
+
 // ValidatePath validates the `.spec.trigger` path.
 func (t *Trigger) ValidatePath(_ context.Context) error {
 	if t.build.Spec.Trigger == nil || len(t.build.Spec.Trigger.When) == 0 {
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/validate"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("ValidateBuildTriggers", func() {
@@ -70,6 +71,7 @@ var _ = Describe("ValidateBuildTriggers", func() {
 			b := &build.Build{
 				Spec: build.BuildSpec{
 					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
 						When: []build.TriggerWhen{{
 							Name: "github",
 							Type: build.GitHubWebHookTrigger,
@@ -88,6 +90,437 @@ var _ = Describe("ValidateBuildTriggers", func() {
 		})
 	})
 
+	Context("trigger type gitlab", func() {
+		It("should error when gitlab attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGitLabWebHook))
+		})
+
+		It("should error when gitlab events attribute is empty", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab.events`"))
+		})
+
+		It("should error when gitlab events contain an unsupported event", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{"PullRequest"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an invalid event \"PullRequest\" in `.gitlab.events`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGitLabWebHook))
+		})
+
+		It("should pass when gitlab type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{
+									build.GitLabPushEvent,
+								},
+								Branches: []string{"main"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type gitea", func() {
+		It("should error when gitea attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitea`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGiteaWebHook))
+		})
+
+		It("should error when gitea events attribute is empty", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+							Gitea: &build.WhenGitea{
+								Events: []build.GiteaEventName{},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitea.events`"))
+		})
+
+		It("should error when gitea events contain an unsupported event", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+							Gitea: &build.WhenGitea{
+								Events: []build.GiteaEventName{"MergeRequest"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an invalid event \"MergeRequest\" in `.gitea.events`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGiteaWebHook))
+		})
+
+		It("should pass when gitea type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+							Gitea: &build.WhenGitea{
+								Events: []build.GiteaEventName{
+									build.GiteaPushEvent,
+								},
+								Branches: []string{"main"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type bitbucket", func() {
+		It("should error when bitbucket attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.bitbucket`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidBitbucketWebHook))
+		})
+
+		It("should error when bitbucket events attribute is empty", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+							Bitbucket: &build.WhenBitbucket{
+								Events: []build.BitbucketEventName{},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.bitbucket.events`"))
+		})
+
+		It("should error when bitbucket events contain an unsupported event", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+							Bitbucket: &build.WhenBitbucket{
+								Events: []build.BitbucketEventName{"Release"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("contains an invalid event \"Release\" in `.bitbucket.events`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidBitbucketWebHook))
+		})
+
+		It("should pass when bitbucket type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+							Bitbucket: &build.WhenBitbucket{
+								Events: []build.BitbucketEventName{
+									build.BitbucketPushEvent,
+								},
+								Branches: []string{"main"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger secret", func() {
+		It("should error when a webhook trigger condition has no trigger secret", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+							Bitbucket: &build.WhenBitbucket{
+								Events: []build.BitbucketEventName{build.BitbucketPushEvent},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("`.spec.trigger.triggerSecret` is not set"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerSecretNotDefined))
+		})
+
+		It("should pass when only other trigger conditions have no trigger secret", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "base-image",
+							Type: build.ImageTrigger,
+							Image: &build.WhenImage{
+								Names: []string{"registry.example.com/base:latest"},
+							},
+						}},
+					},
+				},
+			}
+
+			Expect(validate.NewTrigger(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+	})
+
+	Context("trigger paths", func() {
+		It("should error when a path pattern is malformed", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "github",
+							Type: build.GitHubWebHookTrigger,
+							GitHub: &build.WhenGitHub{
+								Events:      []build.GitHubEventName{build.GitHubPushEvent},
+								IgnorePaths: []string{"docs/[*.md"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid path pattern \"docs/[*.md\""))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidPathPattern))
+		})
+
+		It("should pass when the path patterns are valid", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						TriggerSecret: ptr.To("webhook-secret"),
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events:      []build.GitLabEventName{build.GitLabPushEvent},
+								Paths:       []string{"services/api", "libs/**/*.go"},
+								IgnorePaths: []string{"**/*.md"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type schedule", func() {
+		It("should error when schedule attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "nightly",
+							Type: build.ScheduleTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.schedule`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidSchedule))
+		})
+
+		It("should error when the cron expression is invalid", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "nightly",
+							Type: build.ScheduleTrigger,
+							Schedule: &build.WhenSchedule{
+								Cron: "0 3 * *",
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid cron expression"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidSchedule))
+		})
+
+		It("should error when the time zone is unknown", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "nightly",
+							Type: build.ScheduleTrigger,
+							Schedule: &build.WhenSchedule{
+								Cron:     "0 3 * * *",
+								TimeZone: ptr.To("Mars/Olympus_Mons"),
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("unknown time zone"))
+		})
+
+		It("should error when the concurrency policy is invalid", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "nightly",
+							Type: build.ScheduleTrigger,
+							Schedule: &build.WhenSchedule{
+								Cron:              "0 3 * * *",
+								ConcurrencyPolicy: ptr.To[build.ScheduleConcurrencyPolicy]("Queue"),
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid concurrency policy"))
+		})
+
+		It("should pass when schedule type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "nightly",
+							Type: build.ScheduleTrigger,
+							Schedule: &build.WhenSchedule{
+								Cron:              "0 3 * * 1-5",
+								TimeZone:          ptr.To("Europe/Berlin"),
+								ConcurrencyPolicy: ptr.To(build.ScheduleConcurrencySkip),
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("trigger type image", func() {
 		It("should error when image attribute is not set", func() {
 			b := &build.Build{
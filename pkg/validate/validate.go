@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 )
 
@@ -41,6 +42,10 @@ const (
 	Tolerations = "tolerations"
 	// SchedulerName for validating `spec.schedulerName` entry
 	SchedulerName = "schedulername"
+	// BundleLimits for validating the limits of source bundles
+	BundleLimits = "bundlelimits"
+	// Network for validating the ConfigMap reference of `spec.network`
+	Network = "network"
 )
 
 const (
@@ -61,6 +66,7 @@ func NewValidation(
 	build *build.Build,
 	client client.Client,
 	scheme *runtime.Scheme,
+	cfg *config.Config,
 ) (BuildPath, error) {
 	switch validationType {
 	case Secrets:
@@ -87,6 +93,10 @@ func NewValidation(
 		return &TolerationsRef{Build: build}, nil
 	case SchedulerName:
 		return &SchedulerNameRef{Build: build}, nil
+	case BundleLimits:
+		return &BundleLimitsRef{Build: build, Limits: cfg.BundleLimits}, nil
+	case Network:
+		return &NetworkRef{Build: build, Client: client}, nil
 	default:
 		return nil, fmt.Errorf("unknown validation type")
 	}
@@ -5,6 +5,7 @@ package conversion_test
 
 import (
 	"context"
+	encodingjson "encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -1186,6 +1187,49 @@ request:
 			// Use ComparableTo and assert the whole object
 			Expect(buildRun).To(BeComparableTo(desiredBuildRun))
 		})
+
+		It("converts the Git revision override into an annotation", func() {
+			// Create the yaml in v1beta1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1beta1
+      kind: BuildRun
+      metadata:
+        name: buildkit-run
+        annotations:
+          foo: bar
+      spec:
+        build:
+          name: a_build
+        source:
+          type: Git
+          git:
+            revision: 6a45e68454ca0f319b1a82c65bea09a10fa9eec6
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			buildRun, err := toV1Alpha1BuildRunObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Annotations).To(Equal(map[string]string{
+				"foo": "bar",
+				v1alpha1.AnnotationBuildRunSourceGitRevision: "6a45e68454ca0f319b1a82c65bea09a10fa9eec6",
+			}))
+			Expect(buildRun.Spec.Sources).To(BeEmpty())
+		})
 	})
 	Context("for a BuildRun CR from v1alpha1 to v1beta1", func() {
 		var desiredAPIVersion = "shipwright.io/v1beta1"
@@ -1253,6 +1297,192 @@ request:
 			Expect(buildRun).To(BeComparableTo(desiredBuildRun))
 		})
 
+		It("restores the Git revision override from an annotation", func() {
+			// Create the yaml in v1alpha1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1alpha1
+      kind: BuildRun
+      metadata:
+        name: buildkit-run
+        annotations:
+          foo: bar
+          buildrun.shipwright.io/source.git.revision: 6a45e68454ca0f319b1a82c65bea09a10fa9eec6
+      spec:
+        buildRef:
+          name: a_build
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			buildRun, err := toV1Beta1BuildRunObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Annotations).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(buildRun.Spec.Source).To(Equal(&v1beta1.BuildRunSource{
+				Type: v1beta1.GitType,
+				Git: &v1beta1.BuildRunGit{
+					Revision: ptr.To("6a45e68454ca0f319b1a82c65bea09a10fa9eec6"),
+				},
+			}))
+		})
+
+		It("does not restore the Git revision override for a local source", func() {
+			// Create the yaml in v1alpha1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1alpha1
+      kind: BuildRun
+      metadata:
+        name: buildkit-run
+        annotations:
+          buildrun.shipwright.io/source.git.revision: 6a45e68454ca0f319b1a82c65bea09a10fa9eec6
+      spec:
+        buildRef:
+          name: a_build
+        sources:
+        - name: foobar
+          type: LocalCopy
+          timeout: 1m
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			buildRun, err := toV1Beta1BuildRunObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Annotations).To(BeEmpty())
+			Expect(buildRun.Spec.Source).ToNot(BeNil())
+			Expect(buildRun.Spec.Source.Type).To(Equal(v1beta1.LocalType))
+			Expect(buildRun.Spec.Source.Git).To(BeNil())
+		})
+
+		It("keeps the signer of the Git source when converting to v1alpha1 and back", func() {
+			betaBuildRun := v1beta1.BuildRun{
+				TypeMeta: v1.TypeMeta{APIVersion: "shipwright.io/v1beta1", Kind: "BuildRun"},
+				ObjectMeta: v1.ObjectMeta{
+					Name: "buildkit-run",
+				},
+				Spec: v1beta1.BuildRunSpec{
+					Build: v1beta1.ReferencedBuild{Name: ptr.To("a_build")},
+				},
+				Status: v1beta1.BuildRunStatus{
+					Source: &v1beta1.SourceResult{
+						Git: &v1beta1.GitSourceResult{
+							CommitSha: "6a45e68454ca0f319b1a82c65bea09a10fa9eec6",
+							Signer:    "somebody@example.com",
+						},
+					},
+				},
+			}
+
+			// convert to v1alpha1 and back using the /convert webhook endpoint
+			object := betaBuildRun.DeepCopyObject()
+			for _, version := range []string{"shipwright.io/v1alpha1", "shipwright.io/v1beta1"} {
+				raw, err := encodingjson.Marshal(object)
+				Expect(err).To(BeNil())
+
+				review, err := encodingjson.Marshal(apiextensionsv1.ConversionReview{
+					TypeMeta: v1.TypeMeta{APIVersion: apiVersion, Kind: "ConversionReview"},
+					Request: &apiextensionsv1.ConversionRequest{
+						UID:               "0000-0000-0000-0000",
+						DesiredAPIVersion: version,
+						Objects:           []runtime.RawExtension{{Raw: raw}},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				conversionReview, err := getConversionReview(string(review))
+				Expect(err).To(BeNil())
+				Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+				convertedObj, err := ToUnstructured(conversionReview)
+				Expect(err).To(BeNil())
+				object = &convertedObj
+			}
+
+			buildRun, err := toV1Beta1BuildRunObject(*object.(*unstructured.Unstructured))
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Annotations).To(BeEmpty())
+			Expect(buildRun.Status.Source).ToNot(BeNil())
+			Expect(buildRun.Status.Source.Git).To(Equal(betaBuildRun.Status.Source.Git))
+		})
+
+		It("keeps the Git revision override when converting to v1alpha1 and back", func() {
+			betaBuildRun := v1beta1.BuildRun{
+				TypeMeta: v1.TypeMeta{APIVersion: "shipwright.io/v1beta1", Kind: "BuildRun"},
+				ObjectMeta: v1.ObjectMeta{
+					Name:        "buildkit-run",
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: v1beta1.BuildRunSpec{
+					Build: v1beta1.ReferencedBuild{Name: ptr.To("a_build")},
+					Source: &v1beta1.BuildRunSource{
+						Type: v1beta1.GitType,
+						Git: &v1beta1.BuildRunGit{
+							Revision: ptr.To("6a45e68454ca0f319b1a82c65bea09a10fa9eec6"),
+						},
+					},
+				},
+			}
+
+			// convert to v1alpha1 and back using the /convert webhook endpoint
+			object := betaBuildRun.DeepCopyObject()
+			for _, version := range []string{"shipwright.io/v1alpha1", "shipwright.io/v1beta1"} {
+				raw, err := encodingjson.Marshal(object)
+				Expect(err).To(BeNil())
+
+				review, err := encodingjson.Marshal(apiextensionsv1.ConversionReview{
+					TypeMeta: v1.TypeMeta{APIVersion: apiVersion, Kind: "ConversionReview"},
+					Request: &apiextensionsv1.ConversionRequest{
+						UID:               "0000-0000-0000-0000",
+						DesiredAPIVersion: version,
+						Objects:           []runtime.RawExtension{{Raw: raw}},
+					},
+				})
+				Expect(err).To(BeNil())
+
+				conversionReview, err := getConversionReview(string(review))
+				Expect(err).To(BeNil())
+				Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+				convertedObj, err := ToUnstructured(conversionReview)
+				Expect(err).To(BeNil())
+				object = &convertedObj
+			}
+
+			buildRun, err := toV1Beta1BuildRunObject(*object.(*unstructured.Unstructured))
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Annotations).To(Equal(betaBuildRun.Annotations))
+			Expect(buildRun.Spec.Source).To(Equal(betaBuildRun.Spec.Source))
+		})
+
 		It("converts for spec a generated serviceAccount", func() {
 			// Create the yaml in v1alpha1
 			buildRunTemplate := `kind: ConversionReview